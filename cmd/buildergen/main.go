@@ -0,0 +1,62 @@
+// Command buildergen writes a fluent builder for each named struct type,
+// with required and non-zero fields taken from builder struct tags. See
+// internal/buildergen for what the builders check.
+//
+// Usage:
+//
+//	buildergen -type T[,T...] [-o file] [file.go]
+//
+// It is meant to run from go generate, with a line such as
+//
+//	//go:generate go run github.com/sumit-covlant/go_tutorial/cmd/buildergen -type Person
+//
+// in the file declaring the types. It then reads $GOFILE; otherwise the
+// file is given as an argument. The builders are written to
+// <first type>_builder.go, in lower case, beside it unless -o says
+// otherwise.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sumit-covlant/go_tutorial/internal/buildergen"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct types to write builders for")
+	output := flag.String("o", "", "output file; default <first type>_builder.go")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: buildergen -type T[,T...] [-o file] [file.go]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	input := os.Getenv("GOFILE")
+	if flag.NArg() == 1 {
+		input = flag.Arg(0)
+	}
+	if flag.NArg() > 1 || input == "" || *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	types := strings.Split(*typeNames, ",")
+	src, err := buildergen.Generate(input, nil, types...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	out := *output
+	if out == "" {
+		out = filepath.Join(filepath.Dir(input), strings.ToLower(types[0])+"_builder.go")
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
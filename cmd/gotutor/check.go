@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+	"github.com/sumit-covlant/go_tutorial/internal/exercise"
+	"github.com/sumit-covlant/go_tutorial/internal/progress"
+)
+
+func runCheck(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	root := flags.String("root", ".", "module root")
+	learner := flags.String("learner", defaultLearner(), "who to record the result for")
+	file := progressFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		listExercises()
+		return nil
+	}
+	// Parsing stops at the exercise name; flags may follow it too.
+	name := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.New("check one exercise at a time")
+	}
+
+	chapter, ex, ok := catalog.LookupExercise(name)
+	if !ok {
+		return fmt.Errorf("unknown exercise %q; see gotutor check", name)
+	}
+	c := &exercise.Checker{Root: *root}
+	r, err := c.Check(context.Background(), chapter, ex)
+	if err != nil {
+		return err
+	}
+	if err := record(*file, progress.Record{
+		Learner: *learner,
+		Chapter: chapter.Number,
+		Kind:    progress.Exercise,
+		Name:    ex.Name,
+		Passed:  r.Passed,
+		Spent:   progress.Seconds(r.Elapsed),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "gotutor check: not recording progress: %v\n", err)
+	}
+
+	stub := filepath.Join(exercise.Dir(chapter), "exercises.go")
+	switch {
+	case r.Passed:
+		fmt.Printf("PASS %s: well done!\n", ex.Name)
+		return nil
+	case r.Stub != "":
+		what := strings.TrimPrefix(r.Stub, "TODO: implement ")
+		fmt.Printf("TODO %s: %s is still a stub.\n", ex.Name, what)
+		fmt.Printf("     Replace the panic in %s with your code, then check again.\n", stub)
+		return errors.New("not started")
+	case len(r.Build) > 0:
+		fmt.Printf("FAIL %s: the code does not compile:\n", ex.Name)
+		printIndented(r.Build)
+	case r.Panic != "":
+		fmt.Printf("FAIL %s: the code panicked: %s\n", ex.Name, r.Panic)
+		printIndented(r.Errors)
+	default:
+		fmt.Printf("FAIL %s: not quite.\n", ex.Name)
+		printIndented(r.Errors)
+	}
+	if len(ex.Hints) > 0 {
+		fmt.Println("\nHints:")
+		for _, h := range ex.Hints {
+			fmt.Printf("  - %s\n", h)
+		}
+	}
+	return errors.New("not passed yet")
+}
+
+func record(file string, r progress.Record) error {
+	if file == "" {
+		return errors.New("no progress file")
+	}
+	store, err := progress.Open(file)
+	if err != nil {
+		return err
+	}
+	if err := store.Add(r); err != nil {
+		store.Close()
+		return err
+	}
+	return store.Close()
+}
+
+func printIndented(lines []string) {
+	for _, l := range lines {
+		fmt.Printf("     %s\n", l)
+	}
+}
+
+func listExercises() {
+	for _, c := range catalog.Chapters() {
+		for _, e := range c.Exercises {
+			fmt.Printf("%2d  %-20s %s\n", c.Number, e.Name, e.Summary)
+		}
+	}
+}
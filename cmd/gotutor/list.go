@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+)
+
+func runList(args []string) error {
+	switch len(args) {
+	case 0:
+		for _, c := range catalog.Chapters() {
+			fmt.Printf("%2d  %-22s %s (%d sections)\n", c.Number, c.Slug, c.Title, len(c.Sections))
+		}
+		return nil
+	case 1:
+		chapter, ok := catalog.Lookup(args[0])
+		if !ok {
+			return fmt.Errorf("unknown chapter %q", args[0])
+		}
+		fmt.Println(chapter)
+		for _, s := range chapter.Sections {
+			fmt.Printf("  %s\n", s.Name)
+		}
+		if len(chapter.Exercises) > 0 {
+			fmt.Println("Exercises (gotutor check <name>):")
+			for _, e := range chapter.Exercises {
+				fmt.Printf("  %-20s %s\n", e.Name, e.Summary)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("too many arguments")
+	}
+}
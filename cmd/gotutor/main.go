@@ -0,0 +1,82 @@
+// Command gotutor lists and runs the example code that accompanies each
+// chapter of the Go tutorial, and serves the chapters as a web site.
+//
+// Usage:
+//
+//	gotutor list                       list chapters
+//	gotutor list <chapter>             list the sections of a chapter
+//	gotutor run <chapter> [section...] run a chapter, or some of its sections
+//	gotutor check [exercise]           list the exercises, or grade one
+//	gotutor progress [-learner name]   report who has read and solved what
+//	gotutor search <query>             search the chapters and example code
+//	gotutor serve [-addr addr] [-run]  serve the chapters over HTTP
+//
+// A chapter is given by number (12) or slug (concurrency). Examples that
+// create files do so in a temporary directory, or in the directory given
+// with run -workdir, and remove them when they finish. With serve -run,
+// readers can also run programs from the pages; see package playground
+// for the limits they run under.
+//
+// gotutor check and the pages served by gotutor serve record each
+// learner's progress in one file, by default progress.jsonl under the
+// user's configuration directory; set GOTUTOR_PROGRESS, or pass -progress,
+// to share one file. check records results under the login name unless
+// GOTUTOR_LEARNER or -learner says otherwise.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "[chapter]", "list chapters, or the sections of one chapter", runList},
+		{"run", "[-workdir dir] <chapter> [section...]", "run a whole chapter or the named sections", runRun},
+		{"check", "[exercise]", "list the exercises, or grade one", runCheck},
+		{"progress", "[-learner name] [-progress file]", "report each learner's progress", runProgress},
+		{"search", "[-n hits] <query>", "search the chapters and example code", runSearch},
+		{"serve", "[-addr addr] [-root dir] [-run]", "serve the chapters as a web site", runServe},
+		{"help", "", "show this help", func([]string) error { usage(os.Stdout); return nil }},
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	name, args := os.Args[1], os.Args[2:]
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(args); err != nil {
+				fmt.Fprintf(os.Stderr, "gotutor %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "gotutor: unknown command %q\n", name)
+	usage(os.Stderr)
+	os.Exit(2)
+}
+
+func usage(w *os.File) {
+	fmt.Fprintln(w, "Usage: gotutor <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %-40s %s\n", c.name, c.usage, c.summary)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+	"github.com/sumit-covlant/go_tutorial/internal/progress"
+	"github.com/sumit-covlant/go_tutorial/internal/site"
+)
+
+func runProgress(args []string) error {
+	flags := flag.NewFlagSet("progress", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	learner := flags.String("learner", "", "report only this learner")
+	file := progressFlag(flags)
+	root := flags.String("root", ".", "directory holding go_tutorial/")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	s, err := site.New(os.DirFS(*root), nil)
+	if err != nil {
+		return err
+	}
+	chapters := make(map[int]*site.Chapter)
+	for _, c := range s.Chapters() {
+		chapters[c.Number] = c
+	}
+
+	records, err := progress.Read(*file)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	last := ""
+	for _, p := range progress.Summarize(records) {
+		if *learner != "" && p.Learner != *learner {
+			continue
+		}
+		if p.Learner != last {
+			if last != "" {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintln(w, p.Learner)
+			last = p.Learner
+		}
+		title, read := "?", fmt.Sprint(len(p.Sections))
+		if c := chapters[p.Chapter]; c != nil {
+			title = c.Title
+			read += fmt.Sprintf("/%d", len(c.Sections))
+		}
+		fmt.Fprintf(w, "  %2d\t%s\t%s read\t%s\t%s\n", p.Chapter, title, read, exerciseStatus(p), formatSpent(p.Spent()))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if last == "" {
+		if *learner != "" {
+			return fmt.Errorf("no progress recorded for %s", *learner)
+		}
+		// Nothing recorded yet is not a mistake: report it, and leave
+		// creating the store to the commands that add to it.
+		fmt.Printf("no progress recorded in %s\n", *file)
+	}
+	return nil
+}
+
+// exerciseStatus describes each exercise of the chapter, tried or not.
+func exerciseStatus(p *progress.Progress) string {
+	var parts []string
+	for _, e := range p.Exercises {
+		switch {
+		case e.Passed:
+			parts = append(parts, e.Name+" passed")
+		case e.Attempts == 1:
+			parts = append(parts, e.Name+" not passed (1 attempt)")
+		default:
+			parts = append(parts, fmt.Sprintf("%s not passed (%d attempts)", e.Name, e.Attempts))
+		}
+	}
+	if c, ok := catalog.Lookup(fmt.Sprint(p.Chapter)); ok {
+		for _, e := range c.Exercises {
+			tried := false
+			for _, t := range p.Exercises {
+				tried = tried || t.Name == e.Name
+			}
+			if !tried {
+				parts = append(parts, e.Name+" not started")
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSpent shows a duration to the minute, e.g. "1h05m" or "12m".
+func formatSpent(d time.Duration) string {
+	m := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case d == 0:
+		return "-"
+	case m == 0:
+		return "<1m"
+	case m < 60:
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh%02dm", m/60, m%60)
+}
+
+// progressFlag adds the -progress flag, which names the progress store.
+func progressFlag(flags *flag.FlagSet) *string {
+	def, _ := progress.DefaultPath()
+	return flags.String("progress", def, "progress file")
+}
+
+// defaultLearner is $GOTUTOR_LEARNER or else the user's login name.
+func defaultLearner() string {
+	if name := os.Getenv("GOTUTOR_LEARNER"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
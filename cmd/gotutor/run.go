@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+)
+
+func runRun(args []string) (err error) {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	workdir := flags.String("workdir", "", "directory for the files examples create (default: a new temporary directory)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
+	if len(args) == 0 {
+		return errors.New("missing chapter; see gotutor list")
+	}
+
+	chapter, ok := catalog.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown chapter %q", args[0])
+	}
+
+	// Check every name first so a typo doesn't leave a half-finished run.
+	for _, name := range args[1:] {
+		if _, ok := chapter.Section(name); !ok {
+			return fmt.Errorf("chapter %d has no section %q; see gotutor list %d", chapter.Number, name, chapter.Number)
+		}
+	}
+
+	if chapter.Workspace != nil {
+		release, err := chapter.Workspace(*workdir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if rerr := release(); rerr != nil && err == nil {
+				err = fmt.Errorf("cleaning up: %v", rerr)
+			}
+		}()
+	}
+
+	if len(args) == 1 {
+		return chapter.Run("")
+	}
+	for _, name := range args[1:] {
+		if err := chapter.Run(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sumit-covlant/go_tutorial/internal/search"
+)
+
+func runSearch(args []string) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	n := flags.Int("n", 10, "number of hits to show")
+	root := flags.String("root", ".", "directory holding go_tutorial/")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *n < 1 {
+		return errors.New("-n must be a positive number")
+	}
+	query := strings.Join(flags.Args(), " ")
+	if query == "" {
+		return errors.New("missing query")
+	}
+
+	ix, err := search.Build(os.DirFS(*root))
+	if err != nil {
+		return err
+	}
+	hits := ix.Search(query, *n)
+	if len(hits) == 0 {
+		return fmt.Errorf("no matches for %q", query)
+	}
+	for _, h := range hits {
+		fmt.Printf("%s:%d  %s\n", h.File, h.Line, h.Title)
+		if h.Snippet != "" {
+			fmt.Printf("    %s\n", h.Snippet)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/playground"
+	"github.com/sumit-covlant/go_tutorial/internal/progress"
+	"github.com/sumit-covlant/go_tutorial/internal/search"
+	"github.com/sumit-covlant/go_tutorial/internal/site"
+)
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	addr := flags.String("addr", "127.0.0.1:3000", "address to listen on")
+	root := flags.String("root", ".", "directory holding go_tutorial/")
+	run := flags.Bool("run", false, "let readers run Go programs and chapter examples from the pages")
+	file := progressFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	var runner http.Handler
+	if *run {
+		// Chapter examples are compiled into this binary.
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		r := playground.New()
+		r.Examples = exe
+		runner = r
+	}
+
+	content := os.DirFS(*root)
+	s, err := site.New(content, runner)
+	if err != nil {
+		return err
+	}
+	ix, err := search.Build(content)
+	if err != nil {
+		return err
+	}
+	store, err := progress.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	sections := make(map[int][]string)
+	for _, c := range s.Chapters() {
+		for _, h := range c.Sections {
+			sections[c.Number] = append(sections[c.Number], h.ID)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/search", ix)
+	// Like /run, recording progress is refused to pages on other sites.
+	progressHandler := &progress.Handler{Store: store, Sections: sections}
+	mux.Handle("/api/progress", http.NewCrossOriginProtection().Handler(progressHandler))
+	mux.Handle("/", s)
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+	fmt.Fprintf(os.Stderr, "serving %d chapters on %s\n", len(s.Chapters()), *addr)
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,96 @@
+// Command snippetcheck type-checks the ```go code blocks in the tutorial's
+// markdown chapters and reports the ones that do not compile.
+//
+// Usage:
+//
+//	snippetcheck [-v] [file.md | dir ...]
+//
+// With no arguments it checks go_tutorial/*.md. Errors are printed as
+// file:line: message, with line numbers in the markdown file, and the exit
+// status is 1 if any block fails. A block that is pseudo-code on purpose
+// can be excluded by putting
+//
+//	<!-- snippetcheck: skip -->
+//
+// on the line before its opening fence.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sumit-covlant/go_tutorial/internal/snippet"
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "also list blocks skipped because they need third-party modules")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: snippetcheck [-v] [file.md | dir ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"go_tutorial"}
+	}
+	files, err := markdownFiles(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snippetcheck: %v\n", err)
+		os.Exit(2)
+	}
+
+	var total snippet.Result
+	checker := snippet.NewChecker()
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "snippetcheck: %v\n", err)
+			os.Exit(2)
+		}
+
+		res := checker.CheckFile(file, src)
+		for _, p := range res.Problems {
+			fmt.Println(p)
+		}
+		if *verbose {
+			for _, b := range res.External {
+				fmt.Printf("%s:%d: skipped: needs third-party modules\n", b.File, b.Line)
+			}
+		}
+
+		total.Blocks += res.Blocks
+		total.Skipped += res.Skipped
+		total.Failed += res.Failed
+		total.External = append(total.External, res.External...)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d blocks in %d files: %d failed, %d marked skip, %d need third-party modules\n",
+		total.Blocks, len(files), total.Failed, total.Skipped, len(total.External))
+	if total.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// markdownFiles expands directories to the .md files directly inside them.
+func markdownFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.md"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
@@ -0,0 +1,5 @@
+module github.com/sumit-covlant/go_tutorial
+
+go 1.25
+
+require github.com/yuin/goldmark v1.8.2
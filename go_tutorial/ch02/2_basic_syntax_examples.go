@@ -1,4 +1,4 @@
-package main
+package ch02
 
 import (
 	"fmt"
@@ -19,8 +19,10 @@ var (
 	port  = 8080
 )
 
-// init function runs before main
-func init() {
+// initialize plays the role of an init function. In a standalone program
+// this would be func init(), which Go runs automatically before main; as a
+// library package that would print on every gotutor start, so Main calls it.
+func initialize() {
 	fmt.Println("Initializing application...")
 }
 
@@ -97,7 +99,10 @@ func getCoordinates() (float64, float64, error) {
 	return 40.7128, -74.0060, nil
 }
 
-func main() {
+// Main runs every example in the chapter, in order.
+func Main() {
+	initialize()
+
 	fmt.Printf("=== %s v%s ===\n", AppName, Version)
 	fmt.Println()
 
@@ -0,0 +1,15 @@
+//go:build !solution
+
+package ch02
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// FizzBuzz returns the numbers from 1 to n as strings, except that
+// multiples of 3 are "Fizz", multiples of 5 are "Buzz" and multiples of
+// both are "FizzBuzz". For n < 1 it returns an empty slice.
+//
+// Exercise: fizzbuzz.
+func FizzBuzz(n int) []string {
+	panic("TODO: implement FizzBuzz")
+}
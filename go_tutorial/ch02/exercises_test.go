@@ -0,0 +1,23 @@
+//go:build grade
+
+package ch02
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFizzBuzz(t *testing.T) {
+	want := []string{"1", "2", "Fizz", "4", "Buzz", "Fizz", "7", "8", "Fizz", "Buzz", "11", "Fizz", "13", "14", "FizzBuzz"}
+	if got := FizzBuzz(15); !slices.Equal(got, want) {
+		t.Errorf("FizzBuzz(15) = %q, want %q", got, want)
+	}
+	if got := FizzBuzz(1); !slices.Equal(got, []string{"1"}) {
+		t.Errorf("FizzBuzz(1) = %q, want [\"1\"]", got)
+	}
+	for _, n := range []int{0, -3} {
+		if got := FizzBuzz(n); got == nil || len(got) != 0 {
+			t.Errorf("FizzBuzz(%d) = %#v, want an empty, non-nil slice", n, got)
+		}
+	}
+}
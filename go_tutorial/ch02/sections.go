@@ -0,0 +1,31 @@
+// Package ch02 holds the runnable examples for chapter 2, Basic Syntax.
+package ch02
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 2,
+	Slug:   "basic_syntax",
+	Title:  "Basic Syntax",
+	Doc:    "2_basic_syntax.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "initialize", Run: initialize},
+		{Name: "greet", Run: greet},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "fizzbuzz",
+			Summary: "Implement FizzBuzz",
+			Test:    "TestFizzBuzz",
+			Hints: []string{
+				"Check divisibility with the remainder operator: i%3 == 0.",
+				"Test for multiples of 15 before multiples of 3 or 5, or they will never be reached.",
+				"strconv.Itoa turns an int into its decimal string.",
+				"Start from []string{} rather than nil so the result is never nil.",
+			},
+		},
+	},
+}
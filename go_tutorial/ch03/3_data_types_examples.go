@@ -1,10 +1,14 @@
-package main
+package ch03
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 	"unicode/utf8"
+
+	"github.com/sumit-covlant/go_tutorial/internal/units"
 )
 
 // Package-level constants using iota
@@ -289,6 +293,13 @@ func demonstrateConstants() {
 	// Using iota
 	fmt.Printf("File permissions - Read: %d, Write: %d, Execute: %d\n", FlagRead, FlagWrite, FlagExecute)
 	fmt.Printf("File sizes - KB: %d, MB: %d, GB: %d, TB: %d\n", KB, MB, GB, TB)
+
+	// A named type can give the constants a readable form
+	size := units.ByteSize(1536 * MB)
+	fmt.Printf("1536 MB: %s (IEC), %s (SI)\n", size, size.SI())
+	if parsed, err := units.ParseByteSize("512MiB"); err == nil {
+		fmt.Printf("Parsed 512MiB: %d bytes\n", parsed)
+	}
 	fmt.Println()
 }
 
@@ -312,7 +323,7 @@ func demonstrateTypeConversion() {
 
 	// String conversions
 	num := 42
-	str1 := string(num)            // Converts to Unicode character
+	str1 := string(rune(num))      // Converts to Unicode character
 	str2 := fmt.Sprintf("%d", num) // Converts to string representation
 
 	fmt.Printf("int: %d -> string (Unicode): %s\n", num, str1)
@@ -365,6 +376,45 @@ func demonstrateCustomTypes() {
 	fmt.Println()
 }
 
+// Function to demonstrate the units package, which takes the Celsius and
+// Fahrenheit idea further
+func demonstrateUnits() {
+	fmt.Println("=== Units of Measure ===")
+
+	// Each scale is its own type; converting is a method call
+	body := units.Fahrenheit(98.6)
+	fmt.Printf("Body temperature: %s = %s = %s\n", body, body.Celsius(), body.Kelvin())
+
+	// Lengths and masses are stored in metres and kilograms
+	marathon := 42.195 * units.Kilometer
+	fmt.Printf("Marathon: %s = %.1f miles\n", marathon, marathon.In(units.Mile))
+	fmt.Printf("Parcel: %s\n", 2.5*units.Pound)
+
+	// Parsing a string picks the unit it names
+	for _, s := range []string{"12 km", "6 ft", "500 g", "90 min", "12 kg"} {
+		if l, err := units.ParseLength(s); err == nil {
+			fmt.Printf("Parsed %q as the length %s\n", s, l)
+		} else {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+
+	// The types implement encoding.TextMarshaler, so JSON shows the units
+	type settings struct {
+		Room    units.Celsius
+		Commute units.Length
+		Timeout units.Duration
+	}
+	data, _ := json.Marshal(settings{21.5, 8 * units.Kilometer, units.Duration(90 * time.Second)})
+	fmt.Printf("JSON: %s\n", data)
+
+	var back settings
+	if err := json.Unmarshal([]byte(`{"Room":"70°F","Commute":"5 mi","Timeout":"2 min"}`), &back); err == nil {
+		fmt.Printf("Read back: %.1f, %s, %s\n", back.Room, back.Commute, back.Timeout)
+	}
+	fmt.Println()
+}
+
 // Function to demonstrate variable scoping
 func demonstrateVariableScoping() {
 	fmt.Println("=== Variable Scoping ===")
@@ -390,7 +440,8 @@ func demonstrateVariableScoping() {
 	fmt.Println()
 }
 
-func main() {
+// Main runs every example in the chapter, in order.
+func Main() {
 	fmt.Printf("=== %s ===\n", "Go Data Types & Variables Examples")
 	fmt.Println()
 
@@ -403,6 +454,7 @@ func main() {
 	demonstrateConstants()
 	demonstrateTypeConversion()
 	demonstrateCustomTypes()
+	demonstrateUnits()
 	demonstrateVariableScoping()
 
 	fmt.Println("=== All examples completed successfully ===")
@@ -0,0 +1,14 @@
+//go:build !solution
+
+package ch03
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Reverse returns s with its characters in reverse order. A character is
+// a rune, not a byte, so Reverse("héllo") is "olléh".
+//
+// Exercise: reverse-string.
+func Reverse(s string) string {
+	panic("TODO: implement Reverse")
+}
@@ -0,0 +1,20 @@
+//go:build grade
+
+package ch03
+
+import "testing"
+
+func TestReverse(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"", ""},
+		{"a", "a"},
+		{"Go", "oG"},
+		{"hello, world", "dlrow ,olleh"},
+		{"héllo", "olléh"},
+		{"日本語", "語本日"},
+	} {
+		if got := Reverse(tt.in); got != tt.want {
+			t.Errorf("Reverse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package ch03 holds the runnable examples for chapter 3, Data Types & Variables.
+package ch03
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 3,
+	Slug:   "data_types_variables",
+	Title:  "Data Types & Variables",
+	Doc:    "3_data_types_variables.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "demonstrateZeroValues", Run: demonstrateZeroValues},
+		{Name: "demonstrateIntegerTypes", Run: demonstrateIntegerTypes},
+		{Name: "demonstrateFloatTypes", Run: demonstrateFloatTypes},
+		{Name: "demonstrateStringTypes", Run: demonstrateStringTypes},
+		{Name: "demonstrateBooleanTypes", Run: demonstrateBooleanTypes},
+		{Name: "demonstrateVariableDeclaration", Run: demonstrateVariableDeclaration},
+		{Name: "demonstrateConstants", Run: demonstrateConstants},
+		{Name: "demonstrateTypeConversion", Run: demonstrateTypeConversion},
+		{Name: "demonstrateCustomTypes", Run: demonstrateCustomTypes},
+		{Name: "demonstrateUnits", Run: demonstrateUnits},
+		{Name: "demonstrateVariableScoping", Run: demonstrateVariableScoping},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "reverse-string",
+			Summary: "Reverse a string by characters, not bytes",
+			Test:    "TestReverse",
+			Hints: []string{
+				"Indexing a string gives bytes; \"é\" is two of them in UTF-8.",
+				"Convert to []rune, reverse that slice, and convert back with string(...).",
+			},
+		},
+	},
+}
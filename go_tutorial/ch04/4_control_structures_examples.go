@@ -1,8 +1,16 @@
-package main
+package ch04
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
+	"slices"
+	"strconv"
+
+	"github.com/sumit-covlant/go_tutorial/internal/bands"
+	"github.com/sumit-covlant/go_tutorial/internal/menu"
 )
 
 // Function to demonstrate if statements
@@ -296,6 +304,26 @@ func demonstrateCommonPatterns() {
 		fmt.Printf("Age %d: %s\n", age, result)
 	}
 
+	// Thresholds loaded from JSON
+	fmt.Println("Pricing tiers from JSON:")
+	var tiers bands.Classifier[float64, string]
+	tiersJSON := `[
+		{"min": 0, "max": 100, "label": "standard"},
+		{"min": 100, "max": 1000, "label": "silver"},
+		{"min": 1000, "label": "gold"}
+	]`
+	if err := json.Unmarshal([]byte(tiersJSON), &tiers); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	for _, total := range []float64{42.5, 100, 2500} {
+		tier, _ := tiers.Lookup(total)
+		fmt.Printf("Order of $%.2f: %s\n", total, tier)
+	}
+	gappy := `[{"min": 0, "max": 100, "label": "standard"}, {"min": 150, "label": "gold"}]`
+	if err := json.Unmarshal([]byte(gappy), &tiers); err != nil {
+		fmt.Printf("Rejected: %v\n", err)
+	}
+
 	// Menu system
 	fmt.Println("Menu system:")
 	choices := []int{1, 2, 3, 4, 5}
@@ -341,18 +369,19 @@ func getUser() (User, error) {
 	return User{Name: "Alice"}, nil
 }
 
+// ageBands holds the age thresholds as data rather than as an if/else
+// ladder; bands.Must panics if they leave a gap or overlap
+var ageBands = bands.Must(
+	bands.Below(0, "Invalid age"),
+	bands.Range(0, 13, "Child"),
+	bands.Range(13, 20, "Teenager"),
+	bands.Range(20, 65, "Adult"),
+	bands.From(65, "Senior"),
+)
+
 func validateAge(age int) string {
-	if age < 0 {
-		return "Invalid age"
-	} else if age < 13 {
-		return "Child"
-	} else if age < 20 {
-		return "Teenager"
-	} else if age < 65 {
-		return "Adult"
-	} else {
-		return "Senior"
-	}
+	category, _ := ageBands.Lookup(age) // the bands cover every int
+	return category
 }
 
 func showMenu() {
@@ -377,6 +406,86 @@ func processChoice(choice int) {
 	}
 }
 
+// Function to demonstrate the menu above built on the internal/menu
+// package, with a user list behind the choices
+func demonstrateMenuFramework() {
+	fmt.Println("=== Menu Framework ===")
+
+	users := &userList{}
+	m := menu.New("User admin")
+	m.Add("add", "Add a user: add NAME", users.add)
+	m.Add("delete", "Delete a user: delete NUMBER", users.delete)
+	m.Add("list", "List users", users.list)
+	settings := m.Sub("settings", "Settings")
+	settings.Add("sort", "Sort the list by name", users.sort)
+
+	// Play a fixed session; menu.Run would read from os.Stdin instead
+	err := m.RunScript(os.Stdout,
+		"add Carol",
+		"1 Alice",
+		"add",
+		"add Bob",
+		"list",
+		"settings",
+		"sort",
+		"back",
+		"3",
+		"delete 2",
+		"delete 7",
+		"list",
+		"exit",
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	fmt.Println()
+}
+
+// userList is the in-memory store behind the menu demo
+type userList struct {
+	names []string
+}
+
+func (u *userList) add(w io.Writer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: add NAME")
+	}
+	u.names = append(u.names, args[0])
+	fmt.Fprintf(w, "Added %s\n", args[0])
+	return nil
+}
+
+func (u *userList) delete(w io.Writer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: delete NUMBER")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(u.names) {
+		return fmt.Errorf("no user number %s", args[0])
+	}
+	fmt.Fprintf(w, "Deleted %s\n", u.names[n-1])
+	u.names = slices.Delete(u.names, n-1, n)
+	return nil
+}
+
+func (u *userList) list(w io.Writer, args []string) error {
+	if len(u.names) == 0 {
+		fmt.Fprintln(w, "No users")
+		return nil
+	}
+	for i, name := range u.names {
+		fmt.Fprintf(w, "%d. %s\n", i+1, name)
+	}
+	return nil
+}
+
+func (u *userList) sort(w io.Writer, args []string) error {
+	slices.Sort(u.names)
+	fmt.Fprintln(w, "Sorted by name")
+	return nil
+}
+
 func processData(data string) error {
 	if data == "" {
 		return fmt.Errorf("data cannot be empty")
@@ -403,7 +512,7 @@ func findNumber(numbers []int, target int) (int, bool) {
 func demonstrateBestPractices() {
 	fmt.Println("=== Best Practices ===")
 
-	// Use switch for multiple conditions
+	// Use a table of bands instead of a long ladder of conditions
 	ages := []int{5, 15, 25, 70}
 	for _, age := range ages {
 		category := getAgeCategory(age)
@@ -441,23 +550,16 @@ func demonstrateBestPractices() {
 }
 
 func getAgeCategory(age int) string {
-	switch {
-	case age < 13:
-		return "Child"
-	case age < 20:
-		return "Teenager"
-	case age < 65:
-		return "Adult"
-	default:
-		return "Senior"
-	}
+	category, _ := ageBands.Lookup(age)
+	return category
 }
 
 func getValue() (int, error) {
 	return 42, nil
 }
 
-func main() {
+// Main runs every example in the chapter, in order.
+func Main() {
 	fmt.Printf("=== %s ===\n", "Go Control Structures Examples")
 	fmt.Println()
 
@@ -467,6 +569,7 @@ func main() {
 	demonstrateBreakAndContinue()
 	demonstrateNestedControlStructures()
 	demonstrateCommonPatterns()
+	demonstrateMenuFramework()
 	demonstrateBestPractices()
 
 	fmt.Println("=== All control structure examples completed successfully ===")
@@ -0,0 +1,15 @@
+//go:build !solution
+
+package ch04
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// LetterGrade converts a score from 0 to 100 to a letter: 90 and above is
+// "A", 80 to 89 "B", 70 to 79 "C", 60 to 69 "D" and anything lower "F".
+// A score outside 0 to 100 is an error.
+//
+// Exercise: letter-grade.
+func LetterGrade(score int) (string, error) {
+	panic("TODO: implement LetterGrade")
+}
@@ -0,0 +1,22 @@
+//go:build solution
+
+package ch04
+
+import "fmt"
+
+func LetterGrade(score int) (string, error) {
+	switch {
+	case score < 0 || score > 100:
+		return "", fmt.Errorf("score %d is outside 0 to 100", score)
+	case score >= 90:
+		return "A", nil
+	case score >= 80:
+		return "B", nil
+	case score >= 70:
+		return "C", nil
+	case score >= 60:
+		return "D", nil
+	default:
+		return "F", nil
+	}
+}
@@ -0,0 +1,22 @@
+//go:build grade
+
+package ch04
+
+import "testing"
+
+func TestLetterGrade(t *testing.T) {
+	for score, want := range map[int]string{
+		100: "A", 90: "A", 89: "B", 80: "B", 79: "C", 70: "C",
+		69: "D", 60: "D", 59: "F", 0: "F",
+	} {
+		got, err := LetterGrade(score)
+		if err != nil || got != want {
+			t.Errorf("LetterGrade(%d) = %q, %v; want %q, nil", score, got, err, want)
+		}
+	}
+	for _, score := range []int{-1, 101, 1000} {
+		if got, err := LetterGrade(score); err == nil {
+			t.Errorf("LetterGrade(%d) = %q with no error; scores outside 0 to 100 are errors", score, got)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package ch04
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	opts := golden.Options{
+		// demonstrateForLoops ranges over a map.
+		Unordered: []string{"Main", "demonstrateForLoops"},
+	}
+	if runtime.GOOS != "linux" {
+		reason := "prints runtime.GOOS; transcripts are recorded on linux"
+		opts.Skip = map[string]string{"Main": reason, "demonstrateSwitchStatements": reason}
+	}
+	golden.TestChapter(t, Chapter, opts)
+}
@@ -0,0 +1,37 @@
+// Package ch04 holds the runnable examples for chapter 4, Control Structures.
+package ch04
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 4,
+	Slug:   "control_structures",
+	Title:  "Control Structures",
+	Doc:    "4_control_structures.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "demonstrateIfStatements", Run: demonstrateIfStatements},
+		{Name: "demonstrateSwitchStatements", Run: demonstrateSwitchStatements},
+		{Name: "demonstrateForLoops", Run: demonstrateForLoops},
+		{Name: "demonstrateBreakAndContinue", Run: demonstrateBreakAndContinue},
+		{Name: "demonstrateNestedControlStructures", Run: demonstrateNestedControlStructures},
+		{Name: "demonstrateCommonPatterns", Run: demonstrateCommonPatterns},
+		{Name: "showMenu", Run: showMenu},
+		{Name: "demonstrateMenuFramework", Run: demonstrateMenuFramework},
+		{Name: "demonstrateBestPractices", Run: demonstrateBestPractices},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "letter-grade",
+			Summary: "Turn a score into a letter grade with a switch",
+			Test:    "TestLetterGrade",
+			Hints: []string{
+				"A switch with no condition tests its cases from top to bottom.",
+				"Handle out-of-range scores first, then check the highest grade first.",
+				"Return an error made with fmt.Errorf or errors.New for bad scores.",
+			},
+		},
+	},
+}
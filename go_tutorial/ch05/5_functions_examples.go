@@ -1,10 +1,17 @@
-package main
+package ch05
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/algo"
+	"github.com/sumit-covlant/go_tutorial/internal/bigmath"
+	"github.com/sumit-covlant/go_tutorial/internal/clock"
+	"github.com/sumit-covlant/go_tutorial/internal/conn"
+	"github.com/sumit-covlant/go_tutorial/internal/fn"
 )
 
 // Basic function examples
@@ -181,6 +188,57 @@ func demonstrateAnonymousFunctions() {
 	fmt.Println()
 }
 
+// Function combinators examples, using the internal/fn package
+func demonstrateCombinators() {
+	fmt.Println("=== Function Combinators ===")
+
+	// Compose and Pipe build new functions out of existing ones
+	incThenSquare := fn.Compose(square, fn.Partial(add, 1))
+	fmt.Printf("square(1 + 4) = %d\n", incThenSquare(4))
+	pipeline := fn.Pipe(fn.Partial(add, 1), square, fn.Partial(multiply, 10))
+	fmt.Printf("(4 + 1)² × 10 = %d\n", pipeline(4))
+
+	// Curry turns multiply(a, b) into multiply(a)(b)
+	triple := fn.Curry(multiply)(3)
+	fmt.Printf("triple(7) = %d\n", triple(7))
+	fmt.Printf("Applied curried add: 10 + 5 = %d\n", applyOperation(10, 5, fn.Uncurry(fn.Curry(add))))
+
+	// Memoize calls the function once per argument
+	calls := createCounter()
+	var count int
+	slowSquare := fn.Memoize(func(n int) int {
+		count = calls()
+		return square(n)
+	})
+	for _, n := range []int{4, 4, 5, 4} {
+		fmt.Printf("slowSquare(%d) = %d\n", n, slowSquare(n))
+	}
+	fmt.Printf("Underlying calls: %d\n", count)
+
+	// Once runs the setup the first time only
+	loadConfig := fn.Once(func() string {
+		fmt.Println("Loading config...")
+		return "debug=true"
+	})
+	fmt.Printf("Config: %s\n", loadConfig())
+	fmt.Printf("Config: %s\n", loadConfig())
+
+	// Throttle drops calls that come too soon; a fake clock stands in for
+	// the time between them
+	clk := clock.NewFake(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	save := fn.Throttle(clk, time.Second, func(doc string) {
+		fmt.Printf("Saved %q at %s\n", doc, clk.Now().Format("15:04:05.000"))
+	})
+	for _, doc := range []string{"H", "He", "Hel", "Hell", "Hello"} {
+		if !save(doc) {
+			fmt.Printf("Skipped %q\n", doc)
+		}
+		clk.Advance(300 * time.Millisecond)
+	}
+
+	fmt.Println()
+}
+
 // Defer examples
 func demonstrateDefer() {
 	fmt.Println("=== Defer Statements ===")
@@ -225,12 +283,24 @@ func demonstrateRecursion() {
 	fact := factorial(n)
 	fmt.Printf("Factorial of %d = %d\n", n, fact)
 
+	// Past 20! the result no longer fits in an int; math/big has no limit
+	fmt.Printf("factorial(21) with int = %d (overflowed)\n", factorial(21))
+	fmt.Printf("Factorial of 21 with math/big = %s\n", bigmath.Factorial(21))
+
 	// Recursion with memoization - fibonacci
 	fmt.Println("Fibonacci numbers:")
 	for i := 0; i <= 10; i++ {
 		fib := fibonacci(i)
 		fmt.Printf("fibonacci(%d) = %d\n", i, fib)
 	}
+	fmt.Printf("fibonacci(100) with fast doubling = %s\n", bigmath.FibFastDoubling(100))
+
+	// Long computations can be cancelled through a context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := bigmath.FibContext(ctx, 10_000_000); err != nil {
+		fmt.Printf("fibonacci(10000000) stopped: %v\n", err)
+	}
 
 	// Recursive function with early exit
 	fmt.Println("Finding number in array:")
@@ -239,6 +309,18 @@ func demonstrateRecursion() {
 	found := binarySearch(numbers, target, 0, len(numbers)-1)
 	fmt.Printf("Found %d: %t\n", target, found)
 
+	// Generic versions in internal/algo return where the target is
+	if i, ok := algo.Search(numbers, target); ok {
+		fmt.Printf("algo.Search found %d at index %d\n", target, i)
+	}
+	scores := []int{70, 85, 85, 85, 92}
+	fmt.Printf("Scores of 85 are at indices %d up to %d\n", algo.LowerBound(scores, 85), algo.UpperBound(scores, 85))
+	names := []string{"dave", "alice", "carol", "bob"}
+	algo.MergeSort(names)
+	fmt.Printf("Merge sorted: %v\n", names)
+	times := []float64{9.8, 10.4, 9.5, 11.2, 10.1}
+	fmt.Printf("Median time: %.1f\n", algo.Select(times, len(times)/2))
+
 	fmt.Println()
 }
 
@@ -284,10 +366,18 @@ func demonstrateOptionalParameters() {
 	}
 	processWithConfig("data2", config2)
 
-	// Using variadic functions for optional parameters
+	// Using functional options for optional parameters
 	connect("localhost")
-	connect("localhost", "https")
-	connect("localhost", "https", "443")
+	connect("localhost", conn.WithTLS())
+	connect("localhost", conn.WithTLS(), conn.WithPort(8443), conn.WithRetries(5))
+	connect("localhost", conn.WithTLS(), conn.WithPlaintext())
+
+	// Settings from the environment fill in what the options leave out
+	os.Setenv("EXAMPLE_PORT", "3000")
+	os.Setenv("EXAMPLE_TIMEOUT", "10s")
+	connect("localhost", conn.WithEnv("EXAMPLE_"), conn.WithTimeout(5*time.Second))
+	os.Unsetenv("EXAMPLE_PORT")
+	os.Unsetenv("EXAMPLE_TIMEOUT")
 
 	fmt.Println()
 }
@@ -591,23 +681,18 @@ func processWithConfig(data string, config *Config) {
 		data, config.Timeout, config.Retries, config.Debug)
 }
 
-func connect(host string, options ...string) {
-	port := "8080"     // default
-	protocol := "http" // default
-
-	for i := 0; i < len(options); i++ {
-		switch options[i] {
-		case "https":
-			protocol = "https"
-		case "8080", "443", "3000":
-			port = options[i]
-		}
+func connect(host string, options ...conn.Option) {
+	cfg, err := conn.New(host, options...)
+	if err != nil {
+		fmt.Printf("Cannot connect: %v\n", err)
+		return
 	}
 
-	fmt.Printf("Connecting to %s://%s:%s\n", protocol, host, port)
+	fmt.Printf("Connecting to %s\n", cfg)
 }
 
-func main() {
+// Main runs every example in the chapter, in order.
+func Main() {
 	fmt.Printf("=== %s ===\n", "Go Functions Examples")
 	fmt.Println()
 
@@ -617,6 +702,7 @@ func main() {
 	demonstrateVariadicFunctions()
 	demonstrateFunctionTypes()
 	demonstrateAnonymousFunctions()
+	demonstrateCombinators()
 	demonstrateDefer()
 	demonstrateRecursion()
 	demonstrateErrorHandling()
@@ -0,0 +1,14 @@
+//go:build !solution
+
+package ch05
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Memoize returns a function that gives the same results as f but calls f
+// at most once for each argument, remembering earlier results.
+//
+// Exercise: memoize.
+func Memoize(f func(int) int) func(int) int {
+	panic("TODO: implement Memoize")
+}
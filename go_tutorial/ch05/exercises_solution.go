@@ -0,0 +1,15 @@
+//go:build solution
+
+package ch05
+
+func Memoize(f func(int) int) func(int) int {
+	cache := make(map[int]int)
+	return func(n int) int {
+		if v, ok := cache[n]; ok {
+			return v
+		}
+		v := f(n)
+		cache[n] = v
+		return v
+	}
+}
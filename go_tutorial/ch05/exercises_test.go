@@ -0,0 +1,31 @@
+//go:build grade
+
+package ch05
+
+import "testing"
+
+func TestMemoize(t *testing.T) {
+	calls := make(map[int]int)
+	square := func(n int) int {
+		calls[n]++
+		return n * n
+	}
+
+	m := Memoize(square)
+	for _, n := range []int{3, 4, 3, 3, -2, 4} {
+		if got := m(n); got != n*n {
+			t.Errorf("memoized square(%d) = %d, want %d", n, got, n*n)
+		}
+	}
+	for n, c := range calls {
+		if c != 1 {
+			t.Errorf("f(%d) was called %d times, want once", n, c)
+		}
+	}
+
+	// Each memoized function has a cache of its own.
+	double := Memoize(func(n int) int { return 2 * n })
+	if got := double(3); got != 6 {
+		t.Errorf("memoized double(3) = %d, want 6; is the cache shared between functions?", got)
+	}
+}
@@ -0,0 +1,42 @@
+// Package ch05 holds the runnable examples for chapter 5, Functions.
+package ch05
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 5,
+	Slug:   "functions",
+	Title:  "Functions",
+	Doc:    "5_functions.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "demonstrateBasicFunctions", Run: demonstrateBasicFunctions},
+		{Name: "demonstrateReturnValues", Run: demonstrateReturnValues},
+		{Name: "demonstrateNamedReturnValues", Run: demonstrateNamedReturnValues},
+		{Name: "demonstrateVariadicFunctions", Run: demonstrateVariadicFunctions},
+		{Name: "demonstrateFunctionTypes", Run: demonstrateFunctionTypes},
+		{Name: "demonstrateAnonymousFunctions", Run: demonstrateAnonymousFunctions},
+		{Name: "demonstrateCombinators", Run: demonstrateCombinators},
+		{Name: "demonstrateDefer", Run: demonstrateDefer},
+		{Name: "demonstrateRecursion", Run: demonstrateRecursion},
+		{Name: "demonstrateErrorHandling", Run: demonstrateErrorHandling},
+		{Name: "demonstrateOptionalParameters", Run: demonstrateOptionalParameters},
+		{Name: "sayHello", Run: sayHello},
+		{Name: "deferExample", Run: deferExample},
+		{Name: "multipleDeferExample", Run: multipleDeferExample},
+		{Name: "deferWithArguments", Run: deferWithArguments},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "memoize",
+			Summary: "Wrap a function in a closure that caches its results",
+			Test:    "TestMemoize",
+			Hints: []string{
+				"Create the map inside Memoize, before returning the inner function, so each memoized function has its own.",
+				"The comma-ok form, v, ok := cache[n], tells a cached 0 apart from a missing entry.",
+			},
+		},
+	},
+}
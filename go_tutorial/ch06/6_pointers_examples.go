@@ -1,12 +1,18 @@
-package main
+package ch06
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/list"
+	"github.com/sumit-covlant/go_tutorial/internal/opt"
 )
 
-func main() {
-	fmt.Println("=== Go Pointers Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Pointers Examples ===")
+	fmt.Println()
 
 	// Basic pointer operations
 	basicPointerOperations()
@@ -235,17 +241,34 @@ func commonPointerPatterns() {
 	fmt.Println("---------------------------")
 
 	// Optional parameters
-	processData("test", nil)
+	processData("test", opt.None[time.Duration]())
+	processData("test", opt.Some(60*time.Second))
+
+	// Optional fields tell "not set" apart from zero
+	var job struct {
+		Name    string                      `json:"name"`
+		Timeout opt.Optional[time.Duration] `json:"timeout,omitzero"`
+		Retries opt.Optional[int]           `json:"retries,omitzero"`
+	}
+	if err := json.Unmarshal([]byte(`{"name":"backup","retries":0}`), &job); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	fmt.Printf("Job %s: timeout %v, retries %v\n", job.Name, job.Timeout, job.Retries)
+	encoded, _ := json.Marshal(job)
+	fmt.Printf("Encoded again: %s\n", encoded)
 
-	customTimeout := 60 * time.Second
-	processData("test", &customTimeout)
+	// Pointers from older APIs convert with Deref and FromPtr
+	var legacyTimeout *time.Duration
+	fmt.Printf("Deref of nil: %v\n", opt.Deref(legacyTimeout, 30*time.Second))
+	legacyTimeout = opt.Ptr(45 * time.Second)
+	fmt.Printf("In minutes: %v\n", opt.Map(opt.FromPtr(legacyTimeout), time.Duration.Minutes))
 
-	// Returning multiple values with pointers
+	// When the error says why there is no result, a plain value is enough
 	result, err := divide(10, 2)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 	} else {
-		fmt.Printf("Result: %.2f\n", *result)
+		fmt.Printf("Result: %.2f\n", result)
 	}
 
 	_, err2 := divide(10, 0)
@@ -253,26 +276,55 @@ func commonPointerPatterns() {
 		fmt.Printf("Error: %v\n", err2)
 	}
 
+	// A lookup can come back empty without anything having gone wrong
+	for _, name := range []string{"backup", "cleanup"} {
+		timeout := lookupTimeout(name)
+		fmt.Printf("Timeout for %s: %v, using %v\n", name, timeout, timeout.OrElse(30*time.Second))
+	}
+
 	// Efficient data structures
 	head := createLinkedList()
 	printList(head)
+
+	// The same idea, generic, from internal/list
+	numbers := list.NewSingly(1, 2, 3)
+	numbers.PushBack(4)
+	numbers.Reverse()
+	fmt.Printf("Reversed generic list: %v\n", numbers)
+
+	ring := list.FromValues("a", "b", "c")
+	ring.Next.Next.Next = ring.Next // c points back to b
+	fmt.Printf("Cycle found: %t, starting at %q\n", list.HasCycle(ring), list.CycleStart(ring).Value)
+
+	var queue list.Deque[string]
+	queue.PushBack("first")
+	queue.PushBack("second")
+	queue.PushFront("urgent")
+	for queue.Len() > 0 {
+		job, _ := queue.PopFront()
+		fmt.Printf("Processing job: %s\n", job)
+	}
 	fmt.Println()
 }
 
-func processData(data string, timeout *time.Duration) {
-	defaultTimeout := 30 * time.Second
-	if timeout == nil {
-		timeout = &defaultTimeout
-	}
-	fmt.Printf("Processing '%s' with timeout: %v\n", data, *timeout)
+func processData(data string, timeout opt.Optional[time.Duration]) {
+	fmt.Printf("Processing '%s' with timeout: %v\n", data, timeout.OrElse(30*time.Second))
 }
 
-func divide(a, b int) (result *float64, err error) {
+func divide(a, b int) (float64, error) {
 	if b == 0 {
-		return nil, fmt.Errorf("division by zero")
+		return 0, fmt.Errorf("division by zero")
+	}
+	return float64(a) / float64(b), nil
+}
+
+var jobTimeouts = map[string]time.Duration{"backup": 10 * time.Minute}
+
+func lookupTimeout(job string) opt.Optional[time.Duration] {
+	if d, ok := jobTimeouts[job]; ok {
+		return opt.Some(d)
 	}
-	res := float64(a) / float64(b)
-	return &res, nil
+	return opt.None[time.Duration]()
 }
 
 type Node struct {
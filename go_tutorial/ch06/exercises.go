@@ -0,0 +1,15 @@
+//go:build !solution
+
+package ch06
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// ReverseList reverses the linked list that starts at head, in place, by
+// pointing each node's Next at the node before it. It returns the new
+// head, which was the last node, or nil for an empty list.
+//
+// Exercise: reverse-list.
+func ReverseList(head *Node) *Node {
+	panic("TODO: implement ReverseList")
+}
@@ -0,0 +1,11 @@
+//go:build solution
+
+package ch06
+
+func ReverseList(head *Node) *Node {
+	var prev *Node
+	for head != nil {
+		head, head.Next, prev = head.Next, prev, head
+	}
+	return prev
+}
@@ -0,0 +1,38 @@
+//go:build grade
+
+package ch06
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReverseList(t *testing.T) {
+	for _, values := range [][]int{nil, {1}, {1, 2}, {1, 2, 3, 4, 5}} {
+		var head *Node
+		var nodes []*Node
+		for i := len(values) - 1; i >= 0; i-- {
+			head = &Node{Value: values[i], Next: head}
+			nodes = append(nodes, head)
+		}
+
+		got := ReverseList(head)
+
+		var gotValues []int
+		for n, steps := got, 0; n != nil; n, steps = n.Next, steps+1 {
+			if steps > len(values) {
+				t.Fatalf("ReverseList(%v) made a cycle", values)
+			}
+			gotValues = append(gotValues, n.Value)
+		}
+		want := slices.Clone(values)
+		slices.Reverse(want)
+		if !slices.Equal(gotValues, want) {
+			t.Errorf("ReverseList(%v) = %v, want %v", values, gotValues, want)
+		}
+		// nodes was built back to front, so its first element is the old tail.
+		if len(nodes) > 0 && got != nodes[0] {
+			t.Errorf("ReverseList(%v) built new nodes; reverse the existing ones by changing their Next pointers", values)
+		}
+	}
+}
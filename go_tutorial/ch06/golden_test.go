@@ -0,0 +1,11 @@
+package ch06
+
+import (
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	golden.TestChapter(t, Chapter, golden.Options{})
+}
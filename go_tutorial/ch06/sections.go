@@ -0,0 +1,39 @@
+// Package ch06 holds the runnable examples for chapter 6, Pointers.
+package ch06
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 6,
+	Slug:   "pointers",
+	Title:  "Pointers",
+	Doc:    "6_pointers.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicPointerOperations", Run: basicPointerOperations},
+		{Name: "pointerDeclaration", Run: pointerDeclaration},
+		{Name: "pointerOperations", Run: pointerOperations},
+		{Name: "pointersAndFunctions", Run: pointersAndFunctions},
+		{Name: "pointersToDifferentTypes", Run: pointersToDifferentTypes},
+		{Name: "nilPointers", Run: nilPointers},
+		{Name: "commonPointerPatterns", Run: commonPointerPatterns},
+		{Name: "pointersAndSlices", Run: pointersAndSlices},
+		{Name: "pointersAndMaps", Run: pointersAndMaps},
+		{Name: "bestPractices", Run: bestPractices},
+		{Name: "performanceConsiderations", Run: performanceConsiderations},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "reverse-list",
+			Summary: "Reverse a linked list in place",
+			Test:    "TestReverseList",
+			Hints: []string{
+				"Walk the list keeping a pointer to the previous node, which starts as nil.",
+				"Save node.Next before you overwrite it, or you lose the rest of the list.",
+				"When the walk ends, the previous node is the new head.",
+			},
+		},
+	},
+}
@@ -1,14 +1,20 @@
-package main
+package ch07
 
 import (
+	"errors"
 	"fmt"
-	"math"
 	"reflect"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/geometry"
+	"github.com/sumit-covlant/go_tutorial/internal/typedid"
+	"github.com/sumit-covlant/go_tutorial/internal/validate"
 )
 
-func main() {
-	fmt.Println("=== Go Structs and Methods Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Structs and Methods Examples ===")
+	fmt.Println()
 
 	// Basic struct operations
 	basicStructOperations()
@@ -86,66 +92,39 @@ func structMethods() {
 	fmt.Println("-----------------")
 
 	// Circle with methods
-	circle := Circle{Radius: 5}
+	circle := geometry.Circle{Radius: 5}
 	fmt.Printf("Circle radius: %.2f\n", circle.Radius)
 	fmt.Printf("Circle area: %.2f\n", circle.Area())
 	fmt.Printf("Circle perimeter: %.2f\n", circle.Perimeter())
 
-	// Modifying through pointer receiver
-	circle.SetRadius(10)
-	fmt.Printf("After setting radius: %.2f\n", circle.Radius)
-	fmt.Printf("New area: %.2f\n", circle.Area())
-
-	// Point with value vs pointer receivers
-	point := Point{X: 3, Y: 4}
-	fmt.Printf("Point: %+v\n", point)
-	fmt.Printf("Distance: %.2f\n", point.Distance())
+	// Value receiver returns a new shape instead of modifying the circle
+	bigger := circle.Scale(2, circle.Center)
+	fmt.Printf("After scaling: %.2f, original: %.2f\n", bigger.Area(), circle.Area())
 
 	// Value receiver doesn't modify original
+	point := Point{X: 3, Y: 4}
 	point.Move(1, 1)
 	fmt.Printf("After Move (value receiver): %+v\n", point)
 
 	// Pointer receiver modifies original
-	pointPtr := &point
-	pointPtr.MovePointer(1, 1)
-	fmt.Printf("After MovePointer (pointer receiver): %+v\n", point)
+	placed := geometry.Pt(3, 4)
+	fmt.Printf("Distance from origin: %.2f\n", placed.Dist(geometry.Point{}))
+	placed.Move(1, 1)
+	fmt.Printf("After Move (pointer receiver): %v\n", placed)
 	fmt.Println()
 }
 
-type Circle struct {
-	Radius float64
-}
-
-func (c Circle) Area() float64 {
-	return math.Pi * c.Radius * c.Radius
-}
-
-func (c Circle) Perimeter() float64 {
-	return 2 * math.Pi * c.Radius
-}
-
-func (c *Circle) SetRadius(radius float64) {
-	c.Radius = radius
-}
-
+// Point keeps integer coordinates so that its Move can show the mistake
+// geometry.Point avoids.
 type Point struct {
 	X, Y int
 }
 
-func (p Point) Distance() float64 {
-	return math.Sqrt(float64(p.X*p.X + p.Y*p.Y))
-}
-
 func (p Point) Move(dx, dy int) {
 	p.X += dx // This modifies the copy, not the original
 	p.Y += dy
 }
 
-func (p *Point) MovePointer(dx, dy int) {
-	p.X += dx // This modifies the original
-	p.Y += dy
-}
-
 func structComposition() {
 	fmt.Println("3. Struct Composition")
 	fmt.Println("----------------------")
@@ -193,10 +172,10 @@ type Dog struct {
 }
 
 type Address struct {
-	Street  string
+	Street  string `validate:"required"`
 	City    string
 	State   string
-	ZipCode string
+	ZipCode string `validate:"required,len=5"`
 }
 
 type Employee struct {
@@ -305,10 +284,10 @@ func interfaceImplementation() {
 	fmt.Println("----------------------------")
 
 	// Shapes implementing Shape interface
-	circle := Circle{Radius: 5}
-	rectangle := Rectangle{Width: 4, Height: 6}
+	circle := geometry.Circle{Radius: 5}
+	rectangle := geometry.Rect(0, 0, 4, 6)
 
-	shapes := []Shape{circle, rectangle}
+	shapes := []geometry.Shape{circle, rectangle}
 	for _, shape := range shapes {
 		fmt.Printf("Shape area: %.2f, perimeter: %.2f\n",
 			shape.Area(), shape.Perimeter())
@@ -320,24 +299,7 @@ func interfaceImplementation() {
 	fmt.Println()
 }
 
-type Shape interface {
-	Area() float64
-	Perimeter() float64
-}
-
-type Rectangle struct {
-	Width, Height float64
-}
-
-func (r Rectangle) Area() float64 {
-	return r.Width * r.Height
-}
-
-func (r Rectangle) Perimeter() float64 {
-	return 2 * (r.Width + r.Height)
-}
-
-func printShapeInfo(s Shape) {
+func printShapeInfo(s geometry.Shape) {
 	fmt.Printf("Area: %.2f, Perimeter: %.2f\n", s.Area(), s.Perimeter())
 }
 
@@ -356,17 +318,51 @@ func structTags() {
 
 	fmt.Printf("User: %+v\n", user)
 
-	// Print JSON tags
+	// Print JSON and validate tags
 	printTags()
+
+	// Check the validate tags' rules with internal/validate
+	if err := validate.Struct(user); err != nil {
+		fmt.Printf("Validation failed: %v\n", err)
+	} else {
+		fmt.Println("User is valid")
+	}
+
+	signup := Signup{
+		User:      User{Name: "Bob", Email: "bob@", Password: "short"},
+		Age:       200,
+		Address:   Address{City: "Springfield", ZipCode: "123"},
+		Referrers: []string{"alice@example.com", "carol"},
+	}
+	var failures validate.Errors
+	if errors.As(validate.Struct(signup), &failures) {
+		fmt.Println("Signup failures:")
+		for _, f := range failures {
+			fmt.Printf("  %s: %s\n", f.Field, f.Message)
+		}
+	}
 	fmt.Println()
 }
 
 type User struct {
-	ID       int       `json:"id" xml:"id"`
-	Name     string    `json:"name" xml:"name"`
-	Email    string    `json:"email" xml:"email"`
-	Password string    `json:"-" xml:"-"` // Don't include in JSON/XML
-	Created  time.Time `json:"created_at" xml:"created"`
+	ID       typedid.ID[User] `json:"id" xml:"id"`
+	Name     string           `json:"name" xml:"name" validate:"required"`
+	Email    string           `json:"email" xml:"email" validate:"required,email"`
+	Password string           `json:"-" xml:"-" validate:"min=8"` // Don't include in JSON/XML
+	Created  time.Time        `json:"created_at" xml:"created"`
+}
+
+// IDPrefix makes user IDs print as "usr_1", so they cannot be taken for
+// the ID of anything else.
+func (User) IDPrefix() string { return "usr" }
+
+// Signup nests a User and an Address, so that validating it checks the
+// rules in all their tags.
+type Signup struct {
+	User      User
+	Age       int `validate:"min=0,max=150"`
+	Address   Address
+	Referrers []string `validate:"max=2,dive,email"`
 }
 
 func printTags() {
@@ -375,7 +371,11 @@ func printTags() {
 
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		fmt.Printf("Field: %s, JSON tag: %s\n", field.Name, field.Tag.Get("json"))
+		fmt.Printf("Field: %s, JSON tag: %s", field.Name, field.Tag.Get("json"))
+		if rules, ok := field.Tag.Lookup("validate"); ok {
+			fmt.Printf(", validate: %s", rules)
+		}
+		fmt.Println()
 	}
 }
 
@@ -403,9 +403,21 @@ func constructorFunctions() {
 	fmt.Println()
 }
 
+//go:generate go run github.com/sumit-covlant/go_tutorial/cmd/buildergen -type Person
+
 type Person struct {
-	Name string
-	Age  int
+	Name string `builder:"required,nonzero"`
+	Age  int    `builder:"required"`
+	City string
+}
+
+// Validate reports a Person that no constructor should make. The generated
+// PersonBuilder calls it from Build.
+func (p *Person) Validate() error {
+	if p.Age < 0 {
+		return fmt.Errorf("age cannot be negative")
+	}
+	return nil
 }
 
 func NewPerson(name string, age int) *Person {
@@ -422,29 +434,35 @@ func NewPersonWithValidation(name string, age int) (*Person, error) {
 	if name == "" {
 		return nil, fmt.Errorf("name cannot be empty")
 	}
-	if age < 0 {
-		return nil, fmt.Errorf("age cannot be negative")
-	}
-
-	return &Person{
+	p := &Person{
 		Name: name,
 		Age:  age,
-	}, nil
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func commonPatterns() {
 	fmt.Println("9. Common Patterns")
 	fmt.Println("-------------------")
 
-	// Builder pattern
-	person := NewPersonBuilder().
+	// Builder pattern, with the builder generated by go generate
+	person, err := NewPersonBuilder().
 		Name("Alice").
 		Age(30).
 		City("New York").
 		Build()
-
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
 	fmt.Printf("Built person: %+v\n", person)
 
+	// The builder collects every problem before Build reports them
+	_, err = NewPersonBuilder().Name("").City("Paris").Build()
+	fmt.Printf("Build errors:\n%v\n", err)
+
 	// Factory pattern
 	dog, _ := NewAnimal("dog")
 	cat, _ := NewAnimal("cat")
@@ -453,40 +471,11 @@ func commonPatterns() {
 	fmt.Printf("Cat sound: %s\n", cat.MakeSound())
 
 	// String method for debugging
-	point := Point{X: 3, Y: 4}
+	point := geometry.Pt(3, 4)
 	fmt.Printf("Point: %s\n", point)
 	fmt.Println()
 }
 
-type PersonBuilder struct {
-	person Person
-}
-
-func NewPersonBuilder() *PersonBuilder {
-	return &PersonBuilder{}
-}
-
-func (pb *PersonBuilder) Name(name string) *PersonBuilder {
-	pb.person.Name = name
-	return pb
-}
-
-func (pb *PersonBuilder) Age(age int) *PersonBuilder {
-	pb.person.Age = age
-	return pb
-}
-
-func (pb *PersonBuilder) City(city string) *PersonBuilder {
-	// Note: Person struct doesn't have City field, but we can add it
-	// For demonstration, we'll just print it
-	fmt.Printf("Setting city to: %s\n", city)
-	return pb
-}
-
-func (pb *PersonBuilder) Build() Person {
-	return pb.person
-}
-
 type AnimalFactory interface {
 	MakeSound() string
 }
@@ -508,10 +497,6 @@ func NewAnimal(animalType string) (AnimalFactory, error) {
 	}
 }
 
-func (p Point) String() string {
-	return fmt.Sprintf("Point(%d, %d)", p.X, p.Y)
-}
-
 func bestPractices() {
 	fmt.Println("10. Best Practices")
 	fmt.Println("-------------------")
@@ -546,7 +531,7 @@ func bestPractices() {
 	fmt.Printf("Counter value: %d\n", counter.GetCount())
 
 	// Implement String() method
-	rect := Rectangle{Width: 10, Height: 5}
+	rect := geometry.Rect(0, 0, 10, 5)
 	fmt.Printf("Rectangle: %s\n", rect)
 	fmt.Println()
 }
@@ -563,10 +548,6 @@ func (c Counter) GetCount() int {
 	return c.count
 }
 
-func (r Rectangle) String() string {
-	return fmt.Sprintf("Rectangle(%.2f x %.2f)", r.Width, r.Height)
-}
-
 func performanceConsiderations() {
 	fmt.Println("11. Performance Considerations")
 	fmt.Println("-------------------------------")
@@ -578,8 +559,8 @@ func performanceConsiderations() {
 	fmt.Printf("Optimized struct: %+v\n", optimized)
 
 	// Method receiver choice
-	smallPoint := Point{X: 3, Y: 4}
-	fmt.Printf("Small point distance: %.2f\n", smallPoint.Distance())
+	smallPoint := geometry.Pt(3, 4)
+	fmt.Printf("Small point distance: %.2f\n", smallPoint.Dist(geometry.Point{}))
 
 	largeStruct := &LargeStruct{Data: [1000]int{1, 2, 3}}
 	largeStruct.Process()
@@ -0,0 +1,14 @@
+//go:build !solution
+
+package ch07
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Scale multiplies both of the point's coordinates by factor. A factor that
+// is not positive is an error and leaves the point unchanged.
+//
+// Exercise: point-scale.
+func (p *Point) Scale(factor int) error {
+	panic("TODO: implement Point.Scale")
+}
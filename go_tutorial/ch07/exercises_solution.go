@@ -0,0 +1,14 @@
+//go:build solution
+
+package ch07
+
+import "fmt"
+
+func (p *Point) Scale(factor int) error {
+	if factor <= 0 {
+		return fmt.Errorf("scale factor must be positive, got %d", factor)
+	}
+	p.X *= factor
+	p.Y *= factor
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build grade
+
+package ch07
+
+import "testing"
+
+func TestPointScale(t *testing.T) {
+	p := Point{X: 2, Y: -3}
+	if err := p.Scale(3); err != nil {
+		t.Fatalf("Scale(3) = %v", err)
+	}
+	if want := (Point{X: 6, Y: -9}); p != want {
+		t.Errorf("after Scale(3) the point (2, -3) is %+v, want %+v", p, want)
+	}
+
+	for _, f := range []int{0, -2} {
+		p := Point{X: 2, Y: -3}
+		if err := p.Scale(f); err == nil {
+			t.Errorf("Scale(%d) returned no error", f)
+		}
+		if want := (Point{X: 2, Y: -3}); p != want {
+			t.Errorf("Scale(%d) changed the point to %+v", f, p)
+		}
+	}
+}
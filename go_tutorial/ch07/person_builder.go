@@ -0,0 +1,61 @@
+// Code generated by buildergen; DO NOT EDIT.
+
+package ch07
+
+import "errors"
+
+// PersonBuilder builds a Person one field at a time. Its methods record any
+// problem with the values they are given, and Build reports them all.
+type PersonBuilder struct {
+	v    Person
+	set  [2]bool // which required fields have been set
+	errs []error
+}
+
+// NewPersonBuilder returns a builder for a Person with every field unset.
+func NewPersonBuilder() *PersonBuilder { return &PersonBuilder{} }
+
+// Name sets Person.Name, which is required and must not be empty.
+func (b *PersonBuilder) Name(v string) *PersonBuilder {
+	if v == "" {
+		b.errs = append(b.errs, errors.New("Person.Name must not be empty"))
+	}
+	b.v.Name = v
+	b.set[0] = true
+	return b
+}
+
+// Age sets Person.Age, which is required.
+func (b *PersonBuilder) Age(v int) *PersonBuilder {
+	b.v.Age = v
+	b.set[1] = true
+	return b
+}
+
+// City sets Person.City.
+func (b *PersonBuilder) City(v string) *PersonBuilder {
+	b.v.City = v
+	return b
+}
+
+// Build returns the Person, or an error joining every problem found:
+// those the setters recorded, required fields never set, and the
+// error from Person's Validate method, if it has one.
+func (b *PersonBuilder) Build() (Person, error) {
+	errs := b.errs[:len(b.errs):len(b.errs)]
+	if !b.set[0] {
+		errs = append(errs, errors.New("Person.Name is required"))
+	}
+	if !b.set[1] {
+		errs = append(errs, errors.New("Person.Age is required"))
+	}
+	if v, ok := any(&b.v).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Person{}, err
+	}
+	return b.v, nil
+}
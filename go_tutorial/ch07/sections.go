@@ -0,0 +1,39 @@
+// Package ch07 holds the runnable examples for chapter 7, Structs and Methods.
+package ch07
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 7,
+	Slug:   "structs_and_methods",
+	Title:  "Structs and Methods",
+	Doc:    "7_structs_and_methods.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicStructOperations", Run: basicStructOperations},
+		{Name: "structMethods", Run: structMethods},
+		{Name: "structComposition", Run: structComposition},
+		{Name: "methodOverriding", Run: methodOverriding},
+		{Name: "multipleEmbedding", Run: multipleEmbedding},
+		{Name: "interfaceImplementation", Run: interfaceImplementation},
+		{Name: "structTags", Run: structTags},
+		{Name: "printTags", Run: printTags},
+		{Name: "constructorFunctions", Run: constructorFunctions},
+		{Name: "commonPatterns", Run: commonPatterns},
+		{Name: "bestPractices", Run: bestPractices},
+		{Name: "performanceConsiderations", Run: performanceConsiderations},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "point-scale",
+			Summary: "Add a Scale method with a pointer receiver to Point",
+			Test:    "TestPointScale",
+			Hints: []string{
+				"A pointer receiver is what lets a method change the value it is called on.",
+				"Check the factor before changing anything, and return an error if it is not positive.",
+			},
+		},
+	},
+}
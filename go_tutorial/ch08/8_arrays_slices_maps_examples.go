@@ -1,12 +1,14 @@
-package main
+package ch08
 
 import (
 	"fmt"
 	"strings"
 )
 
-func main() {
-	fmt.Println("=== Go Arrays, Slices, and Maps Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Arrays, Slices, and Maps Examples ===")
+	fmt.Println()
 
 	// Arrays
 	arrayExamples()
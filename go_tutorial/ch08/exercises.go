@@ -0,0 +1,14 @@
+//go:build !solution
+
+package ch08
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Union returns a new Set holding every item that is in s, in other, or in
+// both. Neither s nor other is changed.
+//
+// Exercise: set-union.
+func (s Set) Union(other Set) Set {
+	panic("TODO: implement Set.Union")
+}
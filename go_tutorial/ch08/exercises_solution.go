@@ -0,0 +1,14 @@
+//go:build solution
+
+package ch08
+
+func (s Set) Union(other Set) Set {
+	u := make(Set, len(s)+len(other))
+	for item := range s {
+		u.Add(item)
+	}
+	for item := range other {
+		u.Add(item)
+	}
+	return u
+}
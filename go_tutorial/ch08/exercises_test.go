@@ -0,0 +1,42 @@
+//go:build grade
+
+package ch08
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func setOf(items ...string) Set {
+	s := NewSet()
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func show(s Set) string { return fmt.Sprint(slices.Sorted(maps.Keys(s))) }
+
+func TestSetUnion(t *testing.T) {
+	for _, tt := range []struct{ a, b, want Set }{
+		{setOf("a", "b"), setOf("b", "c"), setOf("a", "b", "c")},
+		{setOf("a"), setOf(), setOf("a")},
+		{setOf(), setOf(), setOf()},
+		{setOf("x", "y"), setOf("x", "y"), setOf("x", "y")},
+	} {
+		a, b := maps.Clone(tt.a), maps.Clone(tt.b)
+		got := tt.a.Union(tt.b)
+		if got == nil {
+			t.Errorf("%s.Union(%s) = nil, want an empty Set", show(a), show(b))
+			continue
+		}
+		if !maps.Equal(got, tt.want) {
+			t.Errorf("%s.Union(%s) = %s, want %s", show(a), show(b), show(got), show(tt.want))
+		}
+		if !maps.Equal(tt.a, a) || !maps.Equal(tt.b, b) {
+			t.Errorf("%s.Union(%s) changed its operands to %s and %s", show(a), show(b), show(tt.a), show(tt.b))
+		}
+	}
+}
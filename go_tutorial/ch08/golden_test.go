@@ -0,0 +1,14 @@
+package ch08
+
+import (
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	golden.TestChapter(t, Chapter, golden.Options{
+		// mapExamples ranges over maps.
+		Unordered: []string{"Main", "mapExamples"},
+	})
+}
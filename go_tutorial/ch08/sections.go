@@ -0,0 +1,34 @@
+// Package ch08 holds the runnable examples for chapter 8, Arrays, Slices, and Maps.
+package ch08
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 8,
+	Slug:   "arrays_slices_maps",
+	Title:  "Arrays, Slices, and Maps",
+	Doc:    "8_arrays_slices_maps.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "arrayExamples", Run: arrayExamples},
+		{Name: "sliceExamples", Run: sliceExamples},
+		{Name: "mapExamples", Run: mapExamples},
+		{Name: "commonPatterns", Run: commonPatterns},
+		{Name: "performanceConsiderations", Run: performanceConsiderations},
+		{Name: "bestPractices", Run: bestPractices},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "set-union",
+			Summary: "Implement Set.Union",
+			Test:    "TestSetUnion",
+			Hints: []string{
+				"Make a new Set with NewSet or make(Set) rather than adding to s.",
+				"Range over both sets and Add every item; adding an item twice is harmless.",
+				"An empty result should still be a usable Set, not nil.",
+			},
+		},
+	},
+}
@@ -1,17 +1,21 @@
-package main
+package ch09
 
 import (
 	"fmt"
 	"math"
 	"strings"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/typedid"
 )
 
 // This file demonstrates packages and modules concepts
 // In a real project, these would be in separate files and directories
 
-func main() {
-	fmt.Println("=== Go Packages & Modules Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Packages & Modules Examples ===")
+	fmt.Println()
 
 	// Demonstrate package concepts
 	packageExamples()
@@ -48,9 +52,13 @@ func packageExamples() {
 	perimeter := calculateRectanglePerimeter(4.0, 6.0)
 	fmt.Printf("Rectangle perimeter: %.2f\n", perimeter)
 
-	// Using models from "models" package
-	user := createUser("Alice", "alice@example.com")
+	// Using models from "models" package, with IDs from this module's
+	// internal/typedid package
+	var userIDs typedid.Sequence[User]
+	user := createUser(userIDs.Next(), "Alice", "alice@example.com")
 	fmt.Printf("User: %+v\n", user)
+	other := createUser(userIDs.Next(), "Bob", "bob@example.com")
+	fmt.Printf("Next user ID: %s\n", other.ID)
 
 	// Demonstrate package visibility
 	demoVisibility()
@@ -93,14 +101,18 @@ func calculateRectanglePerimeter(width, height float64) float64 {
 
 // Models (simulating models package)
 type User struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        typedid.ID[User] `json:"id"`
+	Name      string           `json:"name"`
+	Email     string           `json:"email"`
+	CreatedAt time.Time        `json:"created_at"`
 }
 
-func createUser(name, email string) *User {
+// IDPrefix makes user IDs print as "usr_1".
+func (User) IDPrefix() string { return "usr" }
+
+func createUser(id typedid.ID[User], name, email string) *User {
 	return &User{
+		ID:        id,
 		Name:      name,
 		Email:     email,
 		CreatedAt: time.Now(),
@@ -0,0 +1,16 @@
+//go:build !solution
+
+package ch09
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// ParseVersion parses a module version such as "v1.8.0" into its major,
+// minor and patch numbers. The leading "v" is required, each number must
+// be a non-negative decimal, and anything else, such as "1.8.0" or
+// "v1.8", is an error.
+//
+// Exercise: parse-version.
+func ParseVersion(v string) (major, minor, patch int, err error) {
+	panic("TODO: implement ParseVersion")
+}
@@ -0,0 +1,26 @@
+//go:build solution
+
+package ch09
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseVersion(v string) (major, minor, patch int, err error) {
+	rest, ok := strings.CutPrefix(v, "v")
+	parts := strings.Split(rest, ".")
+	if !ok || len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("version %q is not of the form vMAJOR.MINOR.PATCH", v)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || p == "" || p[0] == '+' {
+			return 0, 0, 0, fmt.Errorf("version %q: bad number %q", v, p)
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], nil
+}
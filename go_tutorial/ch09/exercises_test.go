@@ -0,0 +1,27 @@
+//go:build grade
+
+package ch09
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	for _, tt := range []struct {
+		v                   string
+		major, minor, patch int
+	}{
+		{"v1.8.0", 1, 8, 0},
+		{"v0.0.1", 0, 0, 1},
+		{"v12.34.56", 12, 34, 56},
+	} {
+		major, minor, patch, err := ParseVersion(tt.v)
+		if err != nil || major != tt.major || minor != tt.minor || patch != tt.patch {
+			t.Errorf("ParseVersion(%q) = %d, %d, %d, %v; want %d, %d, %d, nil",
+				tt.v, major, minor, patch, err, tt.major, tt.minor, tt.patch)
+		}
+	}
+	for _, v := range []string{"", "v", "1.8.0", "v1.8", "v1.8.0.1", "v1.x.0", "v1.-1.0", "v1..0", "V1.2.3"} {
+		if _, _, _, err := ParseVersion(v); err == nil {
+			t.Errorf("ParseVersion(%q) returned no error", v)
+		}
+	}
+}
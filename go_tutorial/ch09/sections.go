@@ -0,0 +1,46 @@
+// Package ch09 holds the runnable examples for chapter 9, Packages & Modules.
+package ch09
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 9,
+	Slug:   "packages_modules",
+	Title:  "Packages & Modules",
+	Doc:    "9_packages_modules.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "packageExamples", Run: packageExamples},
+		{Name: "demoVisibility", Run: demoVisibility},
+		{Name: "moduleExamples", Run: moduleExamples},
+		{Name: "demoVersioning", Run: demoVersioning},
+		{Name: "demoDependencyManagement", Run: demoDependencyManagement},
+		{Name: "packageDocumentationExamples", Run: packageDocumentationExamples},
+		{Name: "testingExamples", Run: testingExamples},
+		{Name: "runTestExamples", Run: runTestExamples},
+		{Name: "runBenchmarkExamples", Run: runBenchmarkExamples},
+		{Name: "bestPracticesExamples", Run: bestPracticesExamples},
+		{Name: "demoPackageDesign", Run: demoPackageDesign},
+		{Name: "demoPackageNaming", Run: demoPackageNaming},
+		{Name: "demoPackageOrganization", Run: demoPackageOrganization},
+		{Name: "demoPackageDependencies", Run: demoPackageDependencies},
+		{Name: "commonPatternsExamples", Run: commonPatternsExamples},
+		{Name: "demoPackageInitialization", Run: demoPackageInitialization},
+		{Name: "demoPackageConfiguration", Run: demoPackageConfiguration},
+		{Name: "demoPackageFactories", Run: demoPackageFactories},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "parse-version",
+			Summary: "Parse a module version such as v1.8.0",
+			Test:    "TestParseVersion",
+			Hints: []string{
+				"strings.CutPrefix removes the leading \"v\" and reports whether it was there.",
+				"strings.Split on \".\" must give exactly three parts.",
+				"strconv.Atoi accepts a leading sign, so reject negative numbers yourself.",
+			},
+		},
+	},
+}
@@ -1,15 +1,20 @@
-package main
+package ch10
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"sort"
+
+	"github.com/sumit-covlant/go_tutorial/internal/geometry"
 )
 
 // This file demonstrates Go interfaces concepts
 
-func main() {
-	fmt.Println("=== Go Interfaces Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Interfaces Examples ===")
+	fmt.Println()
 
 	// Basic interface examples
 	basicInterfaceExamples()
@@ -56,66 +61,41 @@ func basicInterfaceExamples() {
 	fmt.Println("    Area() float64")
 	fmt.Println("    Perimeter() float64")
 	fmt.Println("}")
+	fmt.Println("geometry.Shape adds Bounds, Contains, Translate, Scale and Rotate")
 
 	// Demonstrate interface usage
-	shapes := []Shape{
-		Circle{Radius: 5},
-		Rectangle{Width: 4, Height: 6},
-		Triangle{SideA: 3, SideB: 4, SideC: 5},
+	triangle, err := geometry.TriangleFromSides(3, 4, 5)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+	shapes := []geometry.Shape{
+		geometry.Circle{Radius: 5},
+		geometry.Rect(0, 0, 4, 6),
+		triangle,
 	}
 
 	for i, shape := range shapes {
 		fmt.Printf("Shape %d: Area=%.2f, Perimeter=%.2f\n",
 			i+1, shape.Area(), shape.Perimeter())
 	}
-	fmt.Println()
-}
-
-// Shape interface
-type Shape interface {
-	Area() float64
-	Perimeter() float64
-}
-
-// Circle implements Shape
-type Circle struct {
-	Radius float64
-}
-
-func (c Circle) Area() float64 {
-	return math.Pi * c.Radius * c.Radius
-}
-
-func (c Circle) Perimeter() float64 {
-	return 2 * math.Pi * c.Radius
-}
 
-// Rectangle implements Shape
-type Rectangle struct {
-	Width, Height float64
-}
-
-func (r Rectangle) Area() float64 {
-	return r.Width * r.Height
-}
-
-func (r Rectangle) Perimeter() float64 {
-	return 2 * (r.Width + r.Height)
-}
-
-// Triangle implements Shape
-type Triangle struct {
-	SideA, SideB, SideC float64
-}
-
-func (t Triangle) Area() float64 {
-	// Heron's formula
-	s := (t.SideA + t.SideB + t.SideC) / 2
-	return math.Sqrt(s * (s - t.SideA) * (s - t.SideB) * (s - t.SideC))
-}
-
-func (t Triangle) Perimeter() float64 {
-	return t.SideA + t.SideB + t.SideC
+	// The shapes have a position as well as a size
+	placed := geometry.Shapes{
+		geometry.Circle{Center: geometry.Pt(0, 0), Radius: 5},
+		geometry.Rect(10, 0, 14, 6),
+		triangle.Translate(geometry.Pt(-2, -1)),
+	}
+	for _, s := range placed {
+		fmt.Printf("%s: Area=%.2f, Perimeter=%.2f, Bounds=%v\n",
+			geometry.Kind(s), s.Area(), s.Perimeter(), s.Bounds())
+	}
+	fmt.Printf("Circle meets triangle: %t, rectangle: %t\n",
+		geometry.Intersects(placed[0], placed[2]), geometry.Intersects(placed[0], placed[1]))
+	turned := placed[1].Rotate(math.Pi/2, placed[1].Bounds().Center())
+	fmt.Printf("Rectangle turned a quarter: %T, Area=%.2f\n", turned, turned.Area())
+	data, _ := json.Marshal(placed[:2])
+	fmt.Printf("As JSON: %s\n", data)
+	fmt.Println()
 }
 
 // Interface implementation examples
@@ -555,10 +535,6 @@ func (na *NewsAgency) Attach(observer Observer) {
 	na.observers = append(na.observers, observer)
 }
 
-func (na *NewsAgency) Detach(observer Observer) {
-	// Implementation to remove observer
-}
-
 func (na *NewsAgency) Notify(message string) {
 	for _, observer := range na.observers {
 		observer.Update(message)
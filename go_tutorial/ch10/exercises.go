@@ -0,0 +1,15 @@
+//go:build !solution
+
+package ch10
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Detach removes observer from the agency, so later calls to Notify no
+// longer reach it. The other observers keep their order. Detaching an
+// observer that is not attached does nothing.
+//
+// Exercise: news-agency-detach.
+func (na *NewsAgency) Detach(observer Observer) {
+	panic("TODO: implement NewsAgency.Detach")
+}
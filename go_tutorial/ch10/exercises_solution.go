@@ -0,0 +1,11 @@
+//go:build solution
+
+package ch10
+
+import "slices"
+
+func (na *NewsAgency) Detach(observer Observer) {
+	if i := slices.Index(na.observers, observer); i >= 0 {
+		na.observers = slices.Delete(na.observers, i, i+1)
+	}
+}
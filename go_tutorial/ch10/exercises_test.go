@@ -0,0 +1,48 @@
+//go:build grade
+
+package ch10
+
+import (
+	"slices"
+	"testing"
+)
+
+// recorder is an Observer that remembers what it was told.
+type recorder struct {
+	name string
+	got  *[]string
+}
+
+func (r recorder) Update(message string) { *r.got = append(*r.got, r.name+": "+message) }
+
+func TestNewsAgencyDetach(t *testing.T) {
+	var got []string
+	a, b, c := recorder{"a", &got}, recorder{"b", &got}, recorder{"c", &got}
+
+	var agency NewsAgency
+	var _ Subject = &agency
+	agency.Attach(a)
+	agency.Attach(b)
+	agency.Attach(c)
+
+	agency.Detach(b)
+	agency.Notify("one")
+	if want := []string{"a: one", "c: one"}; !slices.Equal(got, want) {
+		t.Errorf("after detaching b, Notify reached %q, want %q", got, want)
+	}
+
+	got = nil
+	agency.Detach(recorder{"stranger", &got}) // never attached
+	agency.Detach(a)
+	agency.Notify("two")
+	if want := []string{"c: two"}; !slices.Equal(got, want) {
+		t.Errorf("after detaching a as well, Notify reached %q, want %q", got, want)
+	}
+
+	got = nil
+	agency.Detach(c)
+	agency.Notify("three")
+	if len(got) != 0 {
+		t.Errorf("with every observer detached, Notify reached %q", got)
+	}
+}
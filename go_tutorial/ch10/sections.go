@@ -0,0 +1,39 @@
+// Package ch10 holds the runnable examples for chapter 10, Interfaces.
+package ch10
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 10,
+	Slug:   "interfaces",
+	Title:  "Interfaces",
+	Doc:    "10_interfaces.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicInterfaceExamples", Run: basicInterfaceExamples},
+		{Name: "interfaceImplementationExamples", Run: interfaceImplementationExamples},
+		{Name: "interfaceCompositionExamples", Run: interfaceCompositionExamples},
+		{Name: "emptyInterfaceExamples", Run: emptyInterfaceExamples},
+		{Name: "interfaceBestPractices", Run: interfaceBestPractices},
+		{Name: "commonInterfacePatterns", Run: commonInterfacePatterns},
+		{Name: "interfaceVsConcreteTypes", Run: interfaceVsConcreteTypes},
+		{Name: "interfaceDesignPatterns", Run: interfaceDesignPatterns},
+		{Name: "interfaceTesting", Run: interfaceTesting},
+		{Name: "interfacePerformance", Run: interfacePerformance},
+		{Name: "standardLibraryInterfaces", Run: standardLibraryInterfaces},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "news-agency-detach",
+			Summary: "Finish NewsAgency.Detach",
+			Test:    "TestNewsAgencyDetach",
+			Hints: []string{
+				"Interface values can be compared with ==, which compares their dynamic types and values.",
+				"slices.Index finds the observer and slices.Delete removes it, keeping the others in order.",
+				"If the observer is not found, leave the list alone.",
+			},
+		},
+	},
+}
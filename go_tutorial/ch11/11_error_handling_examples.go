@@ -1,4 +1,4 @@
-package main
+package ch11
 
 import (
 	"errors"
@@ -6,12 +6,16 @@ import (
 	"io"
 	"os"
 	"runtime"
+
+	"github.com/sumit-covlant/go_tutorial/internal/typedid"
 )
 
 // This file demonstrates Go error handling concepts
 
-func main() {
-	fmt.Println("=== Go Error Handling Examples ===\n")
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Error Handling Examples ===")
+	fmt.Println()
 
 	// Basic error handling examples
 	basicErrorHandling()
@@ -167,7 +171,7 @@ func errorHandlingPatterns() {
 	}
 
 	// Error checking with errors.Is()
-	user, err := findUser("nonexistent")
+	user, err := findUser(missingUser)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			fmt.Println("User not found")
@@ -238,17 +242,26 @@ func readFile(filename string) error {
 
 // User struct and find function
 type User struct {
-	ID   string
+	ID   typedid.ID[User]
 	Name string
 }
 
-func findUser(id string) (*User, error) {
-	if id == "" {
+// IDPrefix makes user IDs print as "usr_123".
+func (User) IDPrefix() string { return "usr" }
+
+// IDs the simulated lookups below treat specially.
+const (
+	missingUser typedid.ID[User] = 404
+	brokenUser  typedid.ID[User] = 500
+)
+
+func findUser(id typedid.ID[User]) (*User, error) {
+	if id == 0 {
 		return nil, ErrInvalidInput
 	}
 
 	// Simulate not found
-	if id == "nonexistent" {
+	if id == missingUser {
 		return nil, ErrNotFound
 	}
 
@@ -268,12 +281,12 @@ func errorTypesAndCategories() {
 
 	// Error types
 	fmt.Println("\nError types:")
-	notFoundErr := NotFoundError{Resource: "user", ID: "123"}
+	notFoundErr := NotFoundError{Resource: "user", ID: typedid.ID[User](123).String()}
 	fmt.Printf("NotFoundError: %v\n", notFoundErr)
 
 	// Wrapped errors
 	fmt.Println("\nWrapped errors:")
-	err := processUserWithWrapping("nonexistent")
+	err := processUserWithWrapping(missingUser)
 	if err != nil {
 		fmt.Printf("Wrapped error: %v\n", err)
 
@@ -297,7 +310,7 @@ func (e NotFoundError) Error() string {
 }
 
 // Wrapped error example
-func processUserWithWrapping(id string) error {
+func processUserWithWrapping(id typedid.ID[User]) error {
 	user, err := findUser(id)
 	if err != nil {
 		return fmt.Errorf("failed to process user %s: %w", id, err)
@@ -369,7 +382,7 @@ func errorHandlingInContexts() {
 
 	// Database operations context
 	fmt.Println("\nDatabase operations context:")
-	user, err := getUserByIDExample("123")
+	user, err := getUserByIDExample(123)
 	if err != nil {
 		fmt.Printf("Database error: %v\n", err)
 	} else {
@@ -389,11 +402,10 @@ func errorHandlingInContexts() {
 
 // HTTP handler example
 func handleGetUserExample() {
-	// Simulate HTTP request
-	id := "nonexistent"
-
-	if id == "" {
-		fmt.Println("HTTP 400: missing user id")
+	// Simulate HTTP request for /users/usr_404
+	id, err := typedid.Parse[User]("usr_404")
+	if err != nil {
+		fmt.Printf("HTTP 400: %v\n", err)
 		return
 	}
 
@@ -411,14 +423,14 @@ func handleGetUserExample() {
 }
 
 // Database operation example
-func getUserByIDExample(id string) (*User, error) {
+func getUserByIDExample(id typedid.ID[User]) (*User, error) {
 	// Simulate database query
-	if id == "nonexistent" {
+	if id == missingUser {
 		return nil, ErrNotFound
 	}
 
 	// Simulate database error
-	if id == "error" {
+	if id == brokenUser {
 		return nil, fmt.Errorf("database connection failed")
 	}
 
@@ -667,7 +679,7 @@ func (e DatabaseError) Unwrap() error {
 // Simulate database operation
 func insertUser(user *User) error {
 	// Simulate database error
-	if user.ID == "error" {
+	if user.ID == brokenUser {
 		return DatabaseError{
 			Operation: "insert",
 			Table:     "users",
@@ -0,0 +1,16 @@
+//go:build !solution
+
+package ch11
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// ParseAge parses an age given as text, ignoring surrounding spaces.
+// Text that is not a whole number gives an error that wraps the one from
+// strconv, so errors.Is(err, strconv.ErrSyntax) still works. A number
+// outside 0 to 150 gives a ValidationError for the field "age".
+//
+// Exercise: parse-age.
+func ParseAge(s string) (int, error) {
+	panic("TODO: implement ParseAge")
+}
@@ -0,0 +1,20 @@
+//go:build solution
+
+package ch11
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseAge(s string) (int, error) {
+	age, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("parsing age: %w", err)
+	}
+	if age < 0 || age > 150 {
+		return 0, ValidationError{Field: "age", Message: "must be between 0 and 150", Value: age}
+	}
+	return age, nil
+}
@@ -0,0 +1,36 @@
+//go:build grade
+
+package ch11
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParseAge(t *testing.T) {
+	for s, want := range map[string]int{"42": 42, " 7 ": 7, "0": 0, "150": 150} {
+		if got, err := ParseAge(s); err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %d, %v; want %d, nil", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "forty", "4 2", "1.5"} {
+		_, err := ParseAge(s)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("ParseAge(%q) = %v; want an error wrapping strconv.ErrSyntax (use %%w)", s, err)
+		}
+	}
+
+	for _, s := range []string{"-1", "151", "9999"} {
+		_, err := ParseAge(s)
+		var ve ValidationError
+		if !errors.As(err, &ve) {
+			t.Errorf("ParseAge(%q) = %v; want a ValidationError", s, err)
+			continue
+		}
+		if ve.Field != "age" {
+			t.Errorf("ParseAge(%q) gave a ValidationError for field %q, want \"age\"", s, ve.Field)
+		}
+	}
+}
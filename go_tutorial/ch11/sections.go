@@ -0,0 +1,40 @@
+// Package ch11 holds the runnable examples for chapter 11, Error Handling.
+package ch11
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 11,
+	Slug:   "error_handling",
+	Title:  "Error Handling",
+	Doc:    "11_error_handling.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicErrorHandling", Run: basicErrorHandling},
+		{Name: "errorCreationExamples", Run: errorCreationExamples},
+		{Name: "errorHandlingPatterns", Run: errorHandlingPatterns},
+		{Name: "errorTypesAndCategories", Run: errorTypesAndCategories},
+		{Name: "errorHandlingBestPractices", Run: errorHandlingBestPractices},
+		{Name: "errorHandlingInContexts", Run: errorHandlingInContexts},
+		{Name: "handleGetUserExample", Run: handleGetUserExample},
+		{Name: "errorLogging", Run: errorLogging},
+		{Name: "testingErrorHandling", Run: testingErrorHandling},
+		{Name: "runErrorTests", Run: runErrorTests},
+		{Name: "testCustomErrorTypes", Run: testCustomErrorTypes},
+		{Name: "commonPitfalls", Run: commonPitfalls},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "parse-age",
+			Summary: "Parse an age and report errors that callers can inspect",
+			Test:    "TestParseAge",
+			Hints: []string{
+				"Wrap the strconv error with fmt.Errorf and the %w verb, not %v.",
+				"Return ValidationError{Field: \"age\", ...} for numbers out of range.",
+				"strings.TrimSpace removes the surrounding spaces.",
+			},
+		},
+	},
+}
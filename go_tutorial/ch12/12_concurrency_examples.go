@@ -1,16 +1,25 @@
-package main
+package ch12
 
 import (
 	"context"
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/clock"
 )
 
 // This file demonstrates Go concurrency concepts
 
-func main() {
-	fmt.Println("=== Go Concurrency Examples ===\n")
+// clk is the clock the examples sleep and wait on. It is the real clock
+// here; the golden test swaps in a clock.Fake so the chapter runs in
+// virtual time with a reproducible order of events.
+var clk = clock.Real()
+
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go Concurrency Examples ===")
+	fmt.Println()
 
 	// Basic goroutine examples
 	basicGoroutineExamples()
@@ -45,28 +54,28 @@ func basicGoroutineExamples() {
 	// Simple goroutine
 	fmt.Println("Starting simple goroutine...")
 	go sayHello("world")
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 
 	// Goroutine with anonymous function
 	fmt.Println("\nStarting anonymous goroutine...")
 	go func() {
 		fmt.Println("Anonymous goroutine executing")
 	}()
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 
 	// Goroutine with parameters
 	fmt.Println("\nStarting goroutine with parameters...")
 	go func(name string) {
 		fmt.Printf("Hello, %s from goroutine!\n", name)
 	}("Alice")
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 
 	// Multiple goroutines
 	fmt.Println("\nStarting multiple goroutines...")
 	for i := 1; i <= 3; i++ {
 		go worker(fmt.Sprintf("worker%d", i))
 	}
-	time.Sleep(1 * time.Second)
+	clk.Sleep(1 * time.Second)
 	fmt.Println()
 }
 
@@ -78,7 +87,7 @@ func sayHello(name string) {
 // Worker function
 func worker(name string) {
 	fmt.Printf("%s starting\n", name)
-	time.Sleep(500 * time.Millisecond)
+	clk.Sleep(500 * time.Millisecond)
 	fmt.Printf("%s done\n", name)
 }
 
@@ -180,7 +189,7 @@ func closingChannelsExample() {
 	go func() {
 		for i := 0; i < 5; i++ {
 			ch <- i
-			time.Sleep(100 * time.Millisecond)
+			clk.Sleep(100 * time.Millisecond)
 		}
 		close(ch) // Close the channel
 		fmt.Println("Channel closed")
@@ -202,7 +211,7 @@ func producerConsumerExample() {
 		for i := 0; i < 5; i++ {
 			fmt.Printf("Producing: %d\n", i)
 			ch <- i
-			time.Sleep(100 * time.Millisecond)
+			clk.Sleep(100 * time.Millisecond)
 		}
 		close(ch)
 	}()
@@ -220,7 +229,7 @@ func workerPoolExample() {
 
 	// Start workers
 	for i := 0; i < 3; i++ {
-		go worker(i, jobs, results)
+		go poolWorker(i, jobs, results)
 	}
 
 	// Send jobs
@@ -237,10 +246,10 @@ func workerPoolExample() {
 }
 
 // Worker function for worker pool
-func worker(id int, jobs <-chan int, results chan<- int) {
+func poolWorker(id int, jobs <-chan int, results chan<- int) {
 	for job := range jobs {
 		fmt.Printf("Worker %d processing job %d\n", id, job)
-		time.Sleep(100 * time.Millisecond)
+		clk.Sleep(100 * time.Millisecond)
 		results <- job * 2
 	}
 }
@@ -274,12 +283,12 @@ func basicSelectExample() {
 	ch2 := make(chan string)
 
 	go func() {
-		time.Sleep(1 * time.Second)
+		clk.Sleep(1 * time.Second)
 		ch1 <- "one"
 	}()
 
 	go func() {
-		time.Sleep(2 * time.Second)
+		clk.Sleep(2 * time.Second)
 		ch2 <- "two"
 	}()
 
@@ -307,17 +316,17 @@ func selectWithDefaultExample() {
 
 // Select with timeout example
 func selectWithTimeoutExample() {
-	ch := make(chan string)
+	ch := make(chan string, 1) // Buffered so the sender can finish after the timeout
 
 	go func() {
-		time.Sleep(2 * time.Second)
+		clk.Sleep(2 * time.Second)
 		ch <- "result"
 	}()
 
 	select {
 	case result := <-ch:
 		fmt.Printf("Received: %s\n", result)
-	case <-time.After(1 * time.Second):
+	case <-clk.After(1 * time.Second):
 		fmt.Println("Timeout occurred")
 	}
 }
@@ -329,17 +338,17 @@ func selectMultipleChannelsExample() {
 	done := make(chan bool)
 
 	go func() {
-		time.Sleep(500 * time.Millisecond)
+		clk.Sleep(500 * time.Millisecond)
 		ch1 <- "message from ch1"
 	}()
 
 	go func() {
-		time.Sleep(1 * time.Second)
+		clk.Sleep(1 * time.Second)
 		ch2 <- "message from ch2"
 	}()
 
 	go func() {
-		time.Sleep(2 * time.Second)
+		clk.Sleep(2 * time.Second)
 		done <- true
 	}()
 
@@ -486,7 +495,6 @@ func (ds *DataStore) Get(key string) (string, bool) {
 
 // Once example
 func onceExample() {
-	var once sync.Once
 	var wg sync.WaitGroup
 
 	// Multiple calls to GetInstance
@@ -549,25 +557,25 @@ func basicContextExample() {
 	defer cancel()
 
 	go func() {
-		time.Sleep(2 * time.Second)
+		clk.Sleep(2 * time.Second)
 		cancel() // Cancel the context
 	}()
 
 	select {
 	case <-ctx.Done():
 		fmt.Println("Context cancelled")
-	case <-time.After(3 * time.Second):
+	case <-clk.After(3 * time.Second):
 		fmt.Println("Timeout")
 	}
 }
 
 // Context with timeout example
 func contextWithTimeoutExample() {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	ctx, cancel := clk.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
 	go func() {
-		time.Sleep(2 * time.Second)
+		clk.Sleep(2 * time.Second)
 		fmt.Println("Work completed")
 	}()
 
@@ -583,7 +591,7 @@ func contextWithValuesExample() {
 
 	go workerWithContext(ctx)
 
-	time.Sleep(time.Second)
+	clk.Sleep(time.Second)
 }
 
 // Worker with context
@@ -594,7 +602,7 @@ func workerWithContext(ctx context.Context) {
 	select {
 	case <-ctx.Done():
 		fmt.Println("Context cancelled")
-	case <-time.After(500 * time.Millisecond):
+	case <-clk.After(500 * time.Millisecond):
 		fmt.Println("Work completed")
 	}
 }
@@ -604,7 +612,7 @@ func contextCancellationExample() {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	go func() {
-		time.Sleep(1 * time.Second)
+		clk.Sleep(1 * time.Second)
 		fmt.Println("Cancelling context...")
 		cancel()
 	}()
@@ -612,7 +620,7 @@ func contextCancellationExample() {
 	select {
 	case <-ctx.Done():
 		fmt.Println("Context was cancelled")
-	case <-time.After(2 * time.Second):
+	case <-clk.After(2 * time.Second):
 		fmt.Println("Timeout")
 	}
 }
@@ -760,7 +768,7 @@ func rateLimitingExample() {
 	}
 	close(requests)
 
-	limiter := time.Tick(200 * time.Millisecond)
+	limiter := clk.Tick(200 * time.Millisecond)
 
 	for req := range requests {
 		<-limiter // Rate limit
@@ -770,7 +778,7 @@ func rateLimitingExample() {
 
 // Worker pool with context
 func workerPoolWithContextExample() {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	ctx, cancel := clk.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
 	jobs := make(chan int, 10)
@@ -778,7 +786,7 @@ func workerPoolWithContextExample() {
 
 	// Start workers
 	for i := 0; i < 3; i++ {
-		go workerWithContext(ctx, i, jobs, results)
+		go poolWorkerWithContext(ctx, i, jobs, results)
 	}
 
 	// Send jobs
@@ -813,14 +821,22 @@ func workerPoolWithContextExample() {
 	fmt.Println("Worker pool example completed")
 }
 
-// Worker with context
-func workerWithContext(ctx context.Context, id int, jobs <-chan int, results chan<- int) {
+// Worker with context for worker pool
+func poolWorkerWithContext(ctx context.Context, id int, jobs <-chan int, results chan<- int) {
 	for {
 		select {
-		case job := <-jobs:
+		case job, ok := <-jobs:
+			if !ok {
+				return // No more jobs
+			}
 			fmt.Printf("Worker %d processing job %d\n", id, job)
-			time.Sleep(100 * time.Millisecond)
-			results <- job * 2
+			clk.Sleep(100 * time.Millisecond)
+			select {
+			case results <- job * 2:
+			case <-ctx.Done():
+				fmt.Printf("Worker %d cancelled\n", id)
+				return
+			}
 		case <-ctx.Done():
 			fmt.Printf("Worker %d cancelled\n", id)
 			return
@@ -878,7 +894,7 @@ func avoidGoroutineLeaksExample() {
 
 	// Signal cleanup
 	close(done)
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 }
 
 // Use buffered channels appropriately
@@ -900,7 +916,7 @@ func useBufferedChannelsExample() {
 
 // Process function
 func process(job int) int {
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 	return job * 2
 }
 
@@ -913,7 +929,7 @@ func handleChannelClosingExample() {
 		defer close(ch)
 		for i := 0; i < 3; i++ {
 			ch <- i
-			time.Sleep(100 * time.Millisecond)
+			clk.Sleep(100 * time.Millisecond)
 		}
 	}()
 
@@ -932,22 +948,28 @@ func useContextForCancellationExample() {
 	ch := make(chan int)
 
 	go func() {
+		defer close(ch) // Lets the receiver's range loop end
 		for i := 0; i < 10; i++ {
+			// select picks at random among ready cases, so check for
+			// cancellation first or more values may slip through.
+			if ctx.Err() != nil {
+				fmt.Println("Sender cancelled")
+				return
+			}
 			select {
 			case ch <- i:
 				fmt.Printf("Sent: %d\n", i)
-				time.Sleep(100 * time.Millisecond)
+				clk.Sleep(100 * time.Millisecond)
 			case <-ctx.Done():
 				fmt.Println("Sender cancelled")
 				return
 			}
 		}
-		close(ch)
 	}()
 
 	// Cancel after some time
 	go func() {
-		time.Sleep(500 * time.Millisecond)
+		clk.Sleep(500 * time.Millisecond)
 		fmt.Println("Cancelling context...")
 		cancel()
 	}()
@@ -1056,7 +1078,7 @@ func goroutineLeakExample() {
 		for {
 			select {
 			case ch <- 1:
-				time.Sleep(100 * time.Millisecond)
+				clk.Sleep(100 * time.Millisecond)
 			case <-done:
 				fmt.Println("Goroutine cleanup")
 				return
@@ -1065,11 +1087,11 @@ func goroutineLeakExample() {
 	}()
 
 	// Do some work
-	time.Sleep(200 * time.Millisecond)
+	clk.Sleep(200 * time.Millisecond)
 
 	// Signal cleanup
 	close(done)
-	time.Sleep(100 * time.Millisecond)
+	clk.Sleep(100 * time.Millisecond)
 	fmt.Println("Goroutine leak prevented")
 }
 
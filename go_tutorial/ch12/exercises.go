@@ -0,0 +1,15 @@
+//go:build !solution
+
+package ch12
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// Merge returns a channel that delivers every value sent on any of chans
+// and is closed once all of them have been closed. Values from one input
+// keep their order; values from different inputs may interleave.
+//
+// Exercise: merge-channels.
+func Merge(chans ...<-chan int) <-chan int {
+	panic("TODO: implement Merge")
+}
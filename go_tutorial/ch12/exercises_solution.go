@@ -0,0 +1,22 @@
+//go:build solution
+
+package ch12
+
+import "sync"
+
+func Merge(chans ...<-chan int) <-chan int {
+	out := make(chan int)
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Go(func() {
+			for v := range ch {
+				out <- v
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,60 @@
+//go:build grade
+
+package ch12
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func send(values ...int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+func TestMerge(t *testing.T) {
+	out := Merge(send(1, 2, 3), send(10, 20), send())
+
+	var got []int
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case v, ok := <-out:
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, v)
+		case <-timeout:
+			t.Fatalf("Merge's channel was not closed after delivering %v; close it once every input is drained", got)
+		}
+	}
+
+	var small, large []int
+	for _, v := range got {
+		if v < 10 {
+			small = append(small, v)
+		} else {
+			large = append(large, v)
+		}
+	}
+	if !slices.Equal(small, []int{1, 2, 3}) || !slices.Equal(large, []int{10, 20}) {
+		t.Errorf("Merge delivered %v; want 1, 2, 3 and 10, 20, each in order", got)
+	}
+
+	select {
+	case _, ok := <-Merge():
+		if ok {
+			t.Error("Merge() with no inputs delivered a value")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Merge() with no inputs never closed its channel")
+	}
+}
@@ -0,0 +1,100 @@
+package ch12
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/clock"
+	"github.com/sumit-covlant/go_tutorial/internal/golden"
+)
+
+// raceEnabled is set by race_test.go in -race builds.
+var raceEnabled bool
+
+func TestGolden(t *testing.T) {
+	if raceEnabled {
+		t.Skip("raceConditionExample races on purpose, and the detector reorders goroutines")
+	}
+	golden.TestChapter(t, Chapter, golden.Options{
+		Run: runWithFakeClock,
+		// The chapter runs on a clock.Fake, so every sleep, timeout and
+		// ticker fires in a fixed order. What the clock cannot order is
+		// goroutines that are runnable at the same moment; the sections
+		// below start some on purpose, and their lines are sorted before
+		// comparing.
+		Unordered: []string{
+			// They run the sections listed after them.
+			"Main",
+			"channelExamples",
+			"syncPackageExamples",
+			"commonConcurrencyPatterns",
+			"bestPracticesExamples",
+			// Goroutines started in a loop print before they first sleep,
+			// or without sleeping at all.
+			"basicGoroutineExamples",
+			"waitGroupExample",
+			"rwMutexExample",
+			"onceExample",
+			// Workers started together take jobs from one channel, and
+			// send their results back, in any order.
+			"workerPoolExample",
+			"workerPoolWithContextExample",
+			// merge forwards from whichever squarer is ready first.
+			"fanOutFanInExample",
+			// The results come back as the goroutines happen to finish.
+			"useBufferedChannelsExample",
+			// After each handoff on the unbuffered channel, the sender's
+			// "Sent" and the receiver's "Received" race.
+			"avoidGoroutineLeaksExample",
+		},
+	})
+}
+
+// runWithFakeClock runs section against a clock.Fake inside a synctest
+// bubble. The bubble is only there for synctest.Wait, which tells the test
+// when every goroutine is blocked; the test then steps the fake clock,
+// which fires its next timer, so sleeps cost nothing and wake-ups happen
+// one at a time in deadline order. Once the section returns, the clock keeps
+// going until no goroutine is left sleeping; synctest fails the test if
+// any goroutine is still blocked after that.
+func runWithFakeClock(t *testing.T, section func()) {
+	// GetInstance initializes the singleton once per process; start every
+	// run from scratch so it prints the same thing each time.
+	once, instance = sync.Once{}, nil
+	// On a single P the runtime runs goroutines that become runnable
+	// together in the order they did, rather than on whichever thread
+	// gets there first, which keeps even the sections below that are
+	// sorted down to one set of lines.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	synctest.Test(t, func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		clk = fake
+		defer func() { clk = clock.Real() }()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			section()
+		}()
+
+		for {
+			synctest.Wait()
+			select {
+			case <-done:
+				for fake.Sleeping() > 0 {
+					fake.Step()
+					synctest.Wait()
+				}
+				return
+			default:
+			}
+			if !fake.Step() {
+				t.Fatal("section is blocked and no timer is pending")
+			}
+		}
+	})
+}
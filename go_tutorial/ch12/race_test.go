@@ -0,0 +1,5 @@
+//go:build race
+
+package ch12
+
+func init() { raceEnabled = true }
@@ -0,0 +1,67 @@
+// Package ch12 holds the runnable examples for chapter 12, Concurrency.
+package ch12
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 12,
+	Slug:   "concurrency",
+	Title:  "Concurrency",
+	Doc:    "12_concurrency.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicGoroutineExamples", Run: basicGoroutineExamples},
+		{Name: "channelExamples", Run: channelExamples},
+		{Name: "unbufferedChannelExample", Run: unbufferedChannelExample},
+		{Name: "bufferedChannelExample", Run: bufferedChannelExample},
+		{Name: "channelDirectionExample", Run: channelDirectionExample},
+		{Name: "closingChannelsExample", Run: closingChannelsExample},
+		{Name: "producerConsumerExample", Run: producerConsumerExample},
+		{Name: "workerPoolExample", Run: workerPoolExample},
+		{Name: "selectStatementExamples", Run: selectStatementExamples},
+		{Name: "basicSelectExample", Run: basicSelectExample},
+		{Name: "selectWithDefaultExample", Run: selectWithDefaultExample},
+		{Name: "selectWithTimeoutExample", Run: selectWithTimeoutExample},
+		{Name: "selectMultipleChannelsExample", Run: selectMultipleChannelsExample},
+		{Name: "syncPackageExamples", Run: syncPackageExamples},
+		{Name: "waitGroupExample", Run: waitGroupExample},
+		{Name: "mutexExample", Run: mutexExample},
+		{Name: "rwMutexExample", Run: rwMutexExample},
+		{Name: "onceExample", Run: onceExample},
+		{Name: "contextExamples", Run: contextExamples},
+		{Name: "basicContextExample", Run: basicContextExample},
+		{Name: "contextWithTimeoutExample", Run: contextWithTimeoutExample},
+		{Name: "contextWithValuesExample", Run: contextWithValuesExample},
+		{Name: "contextCancellationExample", Run: contextCancellationExample},
+		{Name: "commonConcurrencyPatterns", Run: commonConcurrencyPatterns},
+		{Name: "fanOutFanInExample", Run: fanOutFanInExample},
+		{Name: "pipelineExample", Run: pipelineExample},
+		{Name: "rateLimitingExample", Run: rateLimitingExample},
+		{Name: "workerPoolWithContextExample", Run: workerPoolWithContextExample},
+		{Name: "bestPracticesExamples", Run: bestPracticesExamples},
+		{Name: "avoidGoroutineLeaksExample", Run: avoidGoroutineLeaksExample},
+		{Name: "useBufferedChannelsExample", Run: useBufferedChannelsExample},
+		{Name: "handleChannelClosingExample", Run: handleChannelClosingExample},
+		{Name: "useContextForCancellationExample", Run: useContextForCancellationExample},
+		{Name: "commonPitfallsExamples", Run: commonPitfallsExamples},
+		{Name: "raceConditionExample", Run: raceConditionExample},
+		{Name: "deadlockExample", Run: deadlockExample},
+		{Name: "goroutineLeakExample", Run: goroutineLeakExample},
+		{Name: "safeAlternativesExample", Run: safeAlternativesExample},
+		{Name: "safeChannelExample", Run: safeChannelExample},
+	},
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "merge-channels",
+			Summary: "Fan in several channels with Merge",
+			Test:    "TestMerge",
+			Hints: []string{
+				"Start one goroutine per input that copies its values to the output channel.",
+				"A sync.WaitGroup tells a final goroutine when every copier is done, so it can close the output.",
+				"Return the output channel straight away; the goroutines do the work.",
+			},
+		},
+	},
+}
@@ -1,4 +1,4 @@
-package main
+package ch13
 
 import (
 	"bufio"
@@ -6,15 +6,40 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/bitflag"
+	"github.com/sumit-covlant/go_tutorial/internal/workspace"
 )
 
 // This file demonstrates Go file handling and I/O concepts
 
-func main() {
-	fmt.Println("=== Go File Handling & I/O Examples ===\n")
+// ws is where the examples read and write files, instead of the current
+// directory. UseWorkspace sets it up.
+var ws *workspace.Workspace
+
+// UseWorkspace points the examples at a workspace in dir, or in a new
+// temporary directory if dir is "". The returned function removes every
+// file and directory the examples created there.
+func UseWorkspace(dir string) (release func() error, err error) {
+	w, err := workspace.New(dir)
+	if err != nil {
+		return nil, err
+	}
+	ws = w
+	return func() error {
+		ws = nil
+		return w.Close()
+	}, nil
+}
+
+// Main runs every example in the chapter, in order.
+func Main() {
+	fmt.Println("=== Go File Handling & I/O Examples ===")
+	fmt.Println()
 
 	// Basic file operations
 	basicFileOperations()
@@ -72,12 +97,12 @@ func basicFileOperations() {
 // Open file example
 func openFileExample() {
 	// Open file for reading
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		// Create a sample file for demonstration
 		createSampleFile()
-		file, err = os.Open("example.txt")
+		file, err = ws.Open("example.txt")
 		if err != nil {
 			fmt.Printf("Error opening file after creation: %v\n", err)
 			return
@@ -91,7 +116,7 @@ func openFileExample() {
 // Create sample file for examples
 func createSampleFile() {
 	content := "Hello, World!\nThis is a sample file.\nLine 3\nLine 4\nLine 5"
-	err := os.WriteFile("example.txt", []byte(content), 0644)
+	err := ws.WriteFile("example.txt", []byte(content), 0644)
 	if err != nil {
 		fmt.Printf("Error creating sample file: %v\n", err)
 	} else {
@@ -134,6 +159,22 @@ func filePermissionsExample() {
 	for _, perm := range permissions {
 		fmt.Printf("- %s: %o\n", perm.name, perm.mode)
 	}
+
+	// The same modes as flag sets for each class of users
+	for _, perm := range permissions {
+		m := bitflag.ModeOf(perm.mode)
+		fmt.Printf("- %o = %s: owner %s, group %s, others %s\n", perm.mode, m, m.Owner, m.Group, m.Other)
+	}
+
+	// Building a mode from flags, then taking write access away from the group
+	m := bitflag.Mode{
+		Owner: bitflag.Of(bitflag.Read, bitflag.Write),
+		Group: bitflag.Of(bitflag.Read, bitflag.Write),
+		Other: bitflag.Of(bitflag.Read),
+	}
+	fmt.Printf("- Built: %o (%s)\n", m.FileMode(), m)
+	m.Group = m.Group.Clear(bitflag.Write)
+	fmt.Printf("- Group write cleared: %o (%s), group can write: %t\n", m.FileMode(), m, m.Group.Has(bitflag.Write))
 }
 
 // Reading files
@@ -161,7 +202,7 @@ func readingFileExamples() {
 
 // Read entire file example
 func readEntireFileExample() {
-	data, err := os.ReadFile("example.txt")
+	data, err := ws.ReadFile("example.txt")
 	if err != nil {
 		fmt.Printf("Error reading file: %v\n", err)
 		return
@@ -172,7 +213,7 @@ func readEntireFileExample() {
 
 // Read file line by line example
 func readFileLineByLineExample() {
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -194,7 +235,7 @@ func readFileLineByLineExample() {
 
 // Read with buffer example
 func readWithBufferExample() {
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -219,7 +260,7 @@ func readWithBufferExample() {
 
 // Read specific bytes example
 func readSpecificBytesExample() {
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -269,7 +310,7 @@ func writingFileExamples() {
 func writeEntireFileExample() {
 	content := "Hello, World!\nThis is a test file.\nWritten by Go program."
 
-	err := os.WriteFile("output.txt", []byte(content), 0644)
+	err := ws.WriteFile("output.txt", []byte(content), 0644)
 	if err != nil {
 		fmt.Printf("Error writing file: %v\n", err)
 		return
@@ -280,7 +321,7 @@ func writeEntireFileExample() {
 
 // Write with buffer example
 func writeWithBufferExample() {
-	file, err := os.Create("buffered_output.txt")
+	file, err := ws.Create("buffered_output.txt")
 	if err != nil {
 		fmt.Printf("Error creating file: %v\n", err)
 		return
@@ -316,7 +357,7 @@ func writeWithBufferExample() {
 
 // Append to file example
 func appendToFileExample() {
-	file, err := os.OpenFile("log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	file, err := ws.OpenFile("log.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -352,7 +393,7 @@ func fileInformationExamples() {
 
 // Get file info example
 func getFileInfoExample() {
-	fileInfo, err := os.Stat("example.txt")
+	fileInfo, err := ws.Stat("example.txt")
 	if err != nil {
 		fmt.Printf("Error getting file info: %v\n", err)
 		return
@@ -370,7 +411,7 @@ func checkFileExistenceExample() {
 	files := []string{"example.txt", "nonexistent.txt"}
 
 	for _, filename := range files {
-		if _, err := os.Stat(filename); os.IsNotExist(err) {
+		if _, err := ws.Stat(filename); os.IsNotExist(err) {
 			fmt.Printf("File '%s' does not exist\n", filename)
 		} else {
 			fmt.Printf("File '%s' exists\n", filename)
@@ -399,7 +440,7 @@ func directoryOperations() {
 
 // Read directory contents example
 func readDirectoryContentsExample() {
-	entries, err := os.ReadDir(".")
+	entries, err := ws.ReadDir(".")
 	if err != nil {
 		fmt.Printf("Error reading directory: %v\n", err)
 		return
@@ -423,7 +464,7 @@ func readDirectoryContentsExample() {
 // Create directories example
 func createDirectoriesExample() {
 	// Create single directory
-	err := os.Mkdir("newdir", 0755)
+	err := ws.Mkdir("newdir", 0755)
 	if err != nil {
 		fmt.Printf("Error creating directory: %v\n", err)
 	} else {
@@ -431,7 +472,7 @@ func createDirectoriesExample() {
 	}
 
 	// Create nested directories
-	err = os.MkdirAll("parent/child/grandchild", 0755)
+	err = ws.MkdirAll("parent/child/grandchild", 0755)
 	if err != nil {
 		fmt.Printf("Error creating nested directories: %v\n", err)
 	} else {
@@ -442,14 +483,18 @@ func createDirectoriesExample() {
 // Walk directory tree example
 func walkDirectoryTreeExample() {
 	fmt.Println("Walking current directory:")
-	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+	err := fs.WalkDir(ws.FS(), ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
+		if d.IsDir() {
 			fmt.Printf("Directory: %s\n", path)
 		} else {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
 			fmt.Printf("File: %s (%d bytes)\n", path, info.Size())
 		}
 
@@ -480,20 +525,20 @@ func fileCopyingAndMoving() {
 func copyFileExample() {
 	// Create source file
 	sourceContent := "This is the source file content."
-	err := os.WriteFile("source.txt", []byte(sourceContent), 0644)
+	err := ws.WriteFile("source.txt", []byte(sourceContent), 0644)
 	if err != nil {
 		fmt.Printf("Error creating source file: %v\n", err)
 		return
 	}
 
-	source, err := os.Open("source.txt")
+	source, err := ws.Open("source.txt")
 	if err != nil {
 		fmt.Printf("Error opening source: %v\n", err)
 		return
 	}
 	defer source.Close()
 
-	destination, err := os.Create("destination.txt")
+	destination, err := ws.Create("destination.txt")
 	if err != nil {
 		fmt.Printf("Error creating destination: %v\n", err)
 		return
@@ -513,13 +558,13 @@ func copyFileExample() {
 func moveFileExample() {
 	// Create a file to move
 	content := "This file will be moved."
-	err := os.WriteFile("oldname.txt", []byte(content), 0644)
+	err := ws.WriteFile("oldname.txt", []byte(content), 0644)
 	if err != nil {
 		fmt.Printf("Error creating file to move: %v\n", err)
 		return
 	}
 
-	err = os.Rename("oldname.txt", "newname.txt")
+	err = ws.Rename("oldname.txt", "newname.txt")
 	if err != nil {
 		fmt.Printf("Error renaming file: %v\n", err)
 		return
@@ -546,15 +591,15 @@ func temporaryFileExamples() {
 // Create temporary file example
 func createTemporaryFileExample() {
 	// Create temporary file
-	tempFile, err := os.CreateTemp("", "prefix_*.txt")
+	tempFile, err := ws.CreateTemp("", "prefix_*.txt")
 	if err != nil {
 		fmt.Printf("Error creating temp file: %v\n", err)
 		return
 	}
-	defer os.Remove(tempFile.Name()) // Clean up
+	defer ws.Remove(tempFile.Name()) // Clean up
 	defer tempFile.Close()
 
-	fmt.Printf("Temporary file: %s\n", tempFile.Name())
+	fmt.Printf("Temporary file: %s\n", filepath.Base(tempFile.Name()))
 
 	// Write to temporary file
 	_, err = tempFile.WriteString("Temporary content")
@@ -569,18 +614,18 @@ func createTemporaryFileExample() {
 // Create temporary directory example
 func createTemporaryDirectoryExample() {
 	// Create temporary directory
-	tempDir, err := os.MkdirTemp("", "tempdir_*")
+	tempDir, err := ws.MkdirTemp("", "tempdir_*")
 	if err != nil {
 		fmt.Printf("Error creating temp directory: %v\n", err)
 		return
 	}
-	defer os.RemoveAll(tempDir) // Clean up
+	defer ws.RemoveAll(tempDir) // Clean up
 
 	fmt.Printf("Temporary directory: %s\n", tempDir)
 
 	// Create a file in the temporary directory
 	tempFile := filepath.Join(tempDir, "tempfile.txt")
-	err = os.WriteFile(tempFile, []byte("Temporary file content"), 0644)
+	err = ws.WriteFile(tempFile, []byte("Temporary file content"), 0644)
 	if err != nil {
 		fmt.Printf("Error creating file in temp directory: %v\n", err)
 		return
@@ -624,7 +669,7 @@ func writeJSONToFileExample() {
 	}
 
 	// Write JSON to file
-	file, err := os.Create("person.json")
+	file, err := ws.Create("person.json")
 	if err != nil {
 		fmt.Printf("Error creating file: %v\n", err)
 		return
@@ -645,7 +690,7 @@ func writeJSONToFileExample() {
 
 // Read JSON from file example
 func readJSONFromFileExample() {
-	file, err := os.Open("person.json")
+	file, err := ws.Open("person.json")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -673,7 +718,7 @@ func readJSONArrayExample() {
 		{Name: "Charlie", Age: 35, City: "Chicago"},
 	}
 
-	file, err := os.Create("people.json")
+	file, err := ws.Create("people.json")
 	if err != nil {
 		fmt.Printf("Error creating file: %v\n", err)
 		return
@@ -690,7 +735,7 @@ func readJSONArrayExample() {
 	}
 
 	// Read JSON array
-	file, err = os.Open("people.json")
+	file, err = ws.Open("people.json")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -728,7 +773,7 @@ func csvFileHandling() {
 
 // Write CSV file example
 func writeCSVFileExample() {
-	file, err := os.Create("data.csv")
+	file, err := ws.Create("data.csv")
 	if err != nil {
 		fmt.Printf("Error creating file: %v\n", err)
 		return
@@ -766,7 +811,7 @@ func writeCSVFileExample() {
 
 // Read CSV file example
 func readCSVFileExample() {
-	file, err := os.Open("data.csv")
+	file, err := ws.Open("data.csv")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -817,7 +862,7 @@ func bestPracticesExamples() {
 // Always close files example
 func alwaysCloseFilesExample() {
 	// Good: Use defer to ensure file is closed
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -830,7 +875,7 @@ func alwaysCloseFilesExample() {
 // Check for errors example
 func checkForErrorsExample() {
 	// Always check for errors
-	file, err := os.Open("example.txt")
+	file, err := ws.Open("example.txt")
 	if err != nil {
 		fmt.Printf("Error opening file: %v\n", err)
 		return
@@ -844,20 +889,20 @@ func checkForErrorsExample() {
 func useBufferedIOExample() {
 	// Create source and destination files
 	sourceContent := "This is the source content for buffered I/O example."
-	err := os.WriteFile("source_buffered.txt", []byte(sourceContent), 0644)
+	err := ws.WriteFile("source_buffered.txt", []byte(sourceContent), 0644)
 	if err != nil {
 		fmt.Printf("Error creating source file: %v\n", err)
 		return
 	}
 
-	source, err := os.Open("source_buffered.txt")
+	source, err := ws.Open("source_buffered.txt")
 	if err != nil {
 		fmt.Printf("Error opening source: %v\n", err)
 		return
 	}
 	defer source.Close()
 
-	destination, err := os.Create("destination_buffered.txt")
+	destination, err := ws.Create("destination_buffered.txt")
 	if err != nil {
 		fmt.Printf("Error creating destination: %v\n", err)
 		return
@@ -882,14 +927,14 @@ func handleLargeFilesExample() {
 		largeContent += fmt.Sprintf("Line %d: This is a large file content for demonstration.\n", i)
 	}
 
-	err := os.WriteFile("large_file.txt", []byte(largeContent), 0644)
+	err := ws.WriteFile("large_file.txt", []byte(largeContent), 0644)
 	if err != nil {
 		fmt.Printf("Error creating large file: %v\n", err)
 		return
 	}
 
 	// Process large file in chunks
-	file, err := os.Open("large_file.txt")
+	file, err := ws.Open("large_file.txt")
 	if err != nil {
 		fmt.Printf("Error opening large file: %v\n", err)
 		return
@@ -936,7 +981,7 @@ func commonFileOperations() {
 // File monitoring example
 func fileMonitoringExample() {
 	// Create a file to monitor
-	err := os.WriteFile("monitor.txt", []byte("Initial content"), 0644)
+	err := ws.WriteFile("monitor.txt", []byte("Initial content"), 0644)
 	if err != nil {
 		fmt.Printf("Error creating file to monitor: %v\n", err)
 		return
@@ -946,7 +991,7 @@ func fileMonitoringExample() {
 
 	// Monitor for a few seconds
 	for i := 0; i < 3; i++ {
-		fileInfo, err := os.Stat("monitor.txt")
+		fileInfo, err := ws.Stat("monitor.txt")
 		if err != nil {
 			fmt.Printf("Error checking file: %v\n", err)
 			time.Sleep(time.Second)
@@ -962,7 +1007,7 @@ func fileMonitoringExample() {
 
 		// Modify file after first check
 		if i == 0 {
-			err = os.WriteFile("monitor.txt", []byte("Modified content"), 0644)
+			err = ws.WriteFile("monitor.txt", []byte("Modified content"), 0644)
 			if err != nil {
 				fmt.Printf("Error modifying file: %v\n", err)
 			}
@@ -978,18 +1023,18 @@ func safeFileOperationsExample() {
 
 	// Write to temporary file first
 	content := "This is safe content written atomically."
-	err := os.WriteFile(tempFile, []byte(content), 0644)
+	err := ws.WriteFile(tempFile, []byte(content), 0644)
 	if err != nil {
 		fmt.Printf("Error writing to temp file: %v\n", err)
 		return
 	}
 
 	// Atomic move to final location
-	err = os.Rename(tempFile, finalFile)
+	err = ws.Rename(tempFile, finalFile)
 	if err != nil {
 		fmt.Printf("Error moving file: %v\n", err)
 		// Clean up temp file
-		os.Remove(tempFile)
+		ws.Remove(tempFile)
 		return
 	}
 
@@ -1002,33 +1047,3 @@ func processChunk(data []byte) {
 	// This is a placeholder for actual processing logic
 	_ = data
 }
-
-// Clean up function
-func cleanup() {
-	// Clean up temporary files created during examples
-	files := []string{
-		"output.txt",
-		"buffered_output.txt",
-		"log.txt",
-		"source.txt",
-		"destination.txt",
-		"oldname.txt",
-		"newname.txt",
-		"person.json",
-		"people.json",
-		"data.csv",
-		"source_buffered.txt",
-		"destination_buffered.txt",
-		"large_file.txt",
-		"monitor.txt",
-		"final_output.txt",
-	}
-
-	for _, file := range files {
-		os.Remove(file)
-	}
-
-	// Clean up directories
-	os.RemoveAll("newdir")
-	os.RemoveAll("parent")
-}
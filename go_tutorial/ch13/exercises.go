@@ -0,0 +1,18 @@
+//go:build !solution
+
+package ch13
+
+import "io"
+
+// The exercises for this chapter. Replace each panic with your own code,
+// then grade it with gotutor check <exercise>.
+
+// WordCount reads r to the end and counts how often each word occurs.
+// Words are separated by white space, compared in lower case, and have
+// leading and trailing punctuation removed, so "Go," and "go" are the
+// same word. A read error is returned as it is.
+//
+// Exercise: word-count.
+func WordCount(r io.Reader) (map[string]int, error) {
+	panic("TODO: implement WordCount")
+}
@@ -0,0 +1,25 @@
+//go:build solution
+
+package ch13
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+func WordCount(r io.Reader) (map[string]int, error) {
+	counts := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		w := strings.TrimFunc(sc.Text(), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if w != "" {
+			counts[strings.ToLower(w)]++
+		}
+	}
+	return counts, sc.Err()
+}
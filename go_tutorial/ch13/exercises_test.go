@@ -0,0 +1,38 @@
+//go:build grade
+
+package ch13
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWordCount(t *testing.T) {
+	text := "Go is fun.\nGo, go, GO!\n\n  (fun)  -- is it?"
+	want := map[string]int{"go": 4, "is": 2, "fun": 2, "it": 1}
+	got, err := WordCount(strings.NewReader(text))
+	if err != nil || !maps.Equal(got, want) {
+		t.Errorf("WordCount(%q) = %v, %v; want %v, nil", text, got, err, want)
+	}
+
+	// One byte at a time, as a slow file or network connection might.
+	got, err = WordCount(iotest.OneByteReader(strings.NewReader(text)))
+	if err != nil || !maps.Equal(got, want) {
+		t.Errorf("WordCount read a byte at a time = %v, %v; want %v, nil", got, err, want)
+	}
+
+	got, err = WordCount(strings.NewReader(""))
+	if err != nil || len(got) != 0 {
+		t.Errorf("WordCount(\"\") = %v, %v; want an empty map", got, err)
+	}
+
+	boom := errors.New("disk on fire")
+	_, err = WordCount(io.MultiReader(strings.NewReader("some words "), iotest.ErrReader(boom)))
+	if !errors.Is(err, boom) {
+		t.Errorf("WordCount with a failing reader = %v, want the read error", err)
+	}
+}
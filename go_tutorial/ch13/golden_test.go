@@ -0,0 +1,86 @@
+package ch13
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/golden"
+)
+
+// wantCreated lists the files and directories each run leaves in its
+// workspace. Sections not listed create nothing, or remove what they
+// create.
+var wantCreated = map[string][]string{
+	"Main": {
+		"buffered_output.txt", "data.csv", "destination.txt", "destination_buffered.txt",
+		"example.txt", "final_output.txt", "large_file.txt", "log.txt", "monitor.txt",
+		"newdir/", "newname.txt", "output.txt", "parent/", "parent/child/",
+		"parent/child/grandchild/", "people.json", "person.json", "source.txt",
+		"source_buffered.txt",
+	},
+	"basicFileOperations":       {"example.txt"},
+	"openFileExample":           {"example.txt"},
+	"createSampleFile":          {"example.txt"},
+	"writingFileExamples":       {"buffered_output.txt", "log.txt", "output.txt"},
+	"writeEntireFileExample":    {"output.txt"},
+	"writeWithBufferExample":    {"buffered_output.txt"},
+	"appendToFileExample":       {"log.txt"},
+	"directoryOperations":       {"newdir/", "parent/", "parent/child/", "parent/child/grandchild/"},
+	"createDirectoriesExample":  {"newdir/", "parent/", "parent/child/", "parent/child/grandchild/"},
+	"fileCopyingAndMoving":      {"destination.txt", "newname.txt", "source.txt"},
+	"copyFileExample":           {"destination.txt", "source.txt"},
+	"moveFileExample":           {"newname.txt"},
+	"jsonFileHandling":          {"people.json", "person.json"},
+	"writeJSONToFileExample":    {"person.json"},
+	"readJSONArrayExample":      {"people.json"},
+	"csvFileHandling":           {"data.csv"},
+	"writeCSVFileExample":       {"data.csv"},
+	"bestPracticesExamples":     {"destination_buffered.txt", "large_file.txt", "source_buffered.txt"},
+	"useBufferedIOExample":      {"destination_buffered.txt", "source_buffered.txt"},
+	"handleLargeFilesExample":   {"large_file.txt"},
+	"commonFileOperations":      {"final_output.txt", "monitor.txt"},
+	"fileMonitoringExample":     {"monitor.txt"},
+	"safeFileOperationsExample": {"final_output.txt"},
+}
+
+func TestGolden(t *testing.T) {
+	created := make(map[string][]string)
+	golden.TestChapter(t, Chapter, golden.Options{
+		Run: func(t *testing.T, section func()) {
+			created[path.Base(t.Name())] = runInWorkspace(t, section)
+		},
+	})
+
+	for name, got := range created {
+		if want := wantCreated[name]; !slices.Equal(got, want) {
+			t.Errorf("%s created %q, want %q", name, got, want)
+		}
+	}
+}
+
+// runInWorkspace runs section in a new temporary workspace and returns what
+// it created there. It fails the test if releasing the workspace does not
+// remove it.
+func runInWorkspace(t *testing.T, section func()) []string {
+	t.Helper()
+	release, err := UseWorkspace("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := ws.Dir()
+
+	section()
+	created := ws.Created()
+
+	if err := release(); err != nil {
+		t.Errorf("releasing workspace: %v", err)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("workspace %s still exists after release", dir)
+	}
+	return created
+}
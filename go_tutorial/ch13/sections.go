@@ -0,0 +1,71 @@
+// Package ch13 holds the runnable examples for chapter 13, File Handling & IO.
+package ch13
+
+import "github.com/sumit-covlant/go_tutorial/internal/tutor"
+
+// Chapter lists every example section in this package, in source order,
+// and the chapter's exercises.
+var Chapter = tutor.Chapter{
+	Number: 13,
+	Slug:   "file_handling_io",
+	Title:  "File Handling & IO",
+	Doc:    "13_file_handling_io.md",
+	Main:   Main,
+	Sections: []tutor.Section{
+		{Name: "basicFileOperations", Run: basicFileOperations},
+		{Name: "openFileExample", Run: openFileExample},
+		{Name: "createSampleFile", Run: createSampleFile},
+		{Name: "fileModesExample", Run: fileModesExample},
+		{Name: "filePermissionsExample", Run: filePermissionsExample},
+		{Name: "readingFileExamples", Run: readingFileExamples},
+		{Name: "readEntireFileExample", Run: readEntireFileExample},
+		{Name: "readFileLineByLineExample", Run: readFileLineByLineExample},
+		{Name: "readWithBufferExample", Run: readWithBufferExample},
+		{Name: "readSpecificBytesExample", Run: readSpecificBytesExample},
+		{Name: "writingFileExamples", Run: writingFileExamples},
+		{Name: "writeEntireFileExample", Run: writeEntireFileExample},
+		{Name: "writeWithBufferExample", Run: writeWithBufferExample},
+		{Name: "appendToFileExample", Run: appendToFileExample},
+		{Name: "fileInformationExamples", Run: fileInformationExamples},
+		{Name: "getFileInfoExample", Run: getFileInfoExample},
+		{Name: "checkFileExistenceExample", Run: checkFileExistenceExample},
+		{Name: "directoryOperations", Run: directoryOperations},
+		{Name: "readDirectoryContentsExample", Run: readDirectoryContentsExample},
+		{Name: "createDirectoriesExample", Run: createDirectoriesExample},
+		{Name: "walkDirectoryTreeExample", Run: walkDirectoryTreeExample},
+		{Name: "fileCopyingAndMoving", Run: fileCopyingAndMoving},
+		{Name: "copyFileExample", Run: copyFileExample},
+		{Name: "moveFileExample", Run: moveFileExample},
+		{Name: "temporaryFileExamples", Run: temporaryFileExamples},
+		{Name: "createTemporaryFileExample", Run: createTemporaryFileExample},
+		{Name: "createTemporaryDirectoryExample", Run: createTemporaryDirectoryExample},
+		{Name: "jsonFileHandling", Run: jsonFileHandling},
+		{Name: "writeJSONToFileExample", Run: writeJSONToFileExample},
+		{Name: "readJSONFromFileExample", Run: readJSONFromFileExample},
+		{Name: "readJSONArrayExample", Run: readJSONArrayExample},
+		{Name: "csvFileHandling", Run: csvFileHandling},
+		{Name: "writeCSVFileExample", Run: writeCSVFileExample},
+		{Name: "readCSVFileExample", Run: readCSVFileExample},
+		{Name: "bestPracticesExamples", Run: bestPracticesExamples},
+		{Name: "alwaysCloseFilesExample", Run: alwaysCloseFilesExample},
+		{Name: "checkForErrorsExample", Run: checkForErrorsExample},
+		{Name: "useBufferedIOExample", Run: useBufferedIOExample},
+		{Name: "handleLargeFilesExample", Run: handleLargeFilesExample},
+		{Name: "commonFileOperations", Run: commonFileOperations},
+		{Name: "fileMonitoringExample", Run: fileMonitoringExample},
+		{Name: "safeFileOperationsExample", Run: safeFileOperationsExample},
+	},
+	Workspace: UseWorkspace,
+	Exercises: []tutor.Exercise{
+		{
+			Name:    "word-count",
+			Summary: "Count the words in a reader",
+			Test:    "TestWordCount",
+			Hints: []string{
+				"bufio.NewScanner with bufio.ScanWords splits the input at white space.",
+				"strings.TrimFunc with unicode.IsLetter and unicode.IsDigit strips punctuation.",
+				"Return scanner.Err() once Scan returns false.",
+			},
+		},
+	},
+}
@@ -0,0 +1,208 @@
+package algo
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomSorted returns a sorted slice of n values from [0, spread), which
+// has many duplicates when spread is small.
+func randomSorted(r *rand.Rand, n, spread int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = r.IntN(spread)
+	}
+	slices.Sort(s)
+	return s
+}
+
+func TestSearchProperties(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		s := randomSorted(r, r.IntN(60), 1+r.IntN(100))
+		for x := -1; x <= 101; x++ {
+			wantI, wantOK := slices.BinarySearch(s, x)
+			if i, ok := Search(s, x); i != wantI || ok != wantOK {
+				t.Fatalf("Search(%v, %d) = %d, %t; want %d, %t", s, x, i, ok, wantI, wantOK)
+			}
+			if i, ok := ExponentialSearch(s, x); i != wantI || ok != wantOK {
+				t.Fatalf("ExponentialSearch(%v, %d) = %d, %t; want %d, %t", s, x, i, ok, wantI, wantOK)
+			}
+			if i, ok := InterpolationSearch(s, x); i != wantI || ok != wantOK {
+				t.Fatalf("InterpolationSearch(%v, %d) = %d, %t; want %d, %t", s, x, i, ok, wantI, wantOK)
+			}
+			if lo := LowerBound(s, x); lo != wantI {
+				t.Fatalf("LowerBound(%v, %d) = %d, want %d", s, x, lo, wantI)
+			}
+			hi := UpperBound(s, x)
+			if n := hi - wantI; n < 0 || n != count(s, x) {
+				t.Fatalf("UpperBound(%v, %d) = %d, but %d equal elements start at %d", s, x, hi, count(s, x), wantI)
+			}
+		}
+	}
+}
+
+func count(s []int, x int) int {
+	n := 0
+	for _, v := range s {
+		if v == x {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSearchOtherTypes(t *testing.T) {
+	words := []string{"apple", "banana", "banana", "cherry"}
+	if i, ok := Search(words, "banana"); i != 1 || !ok {
+		t.Errorf("Search(words, banana) = %d, %t", i, ok)
+	}
+	if lo, hi := LowerBound(words, "banana"), UpperBound(words, "banana"); lo != 1 || hi != 3 {
+		t.Errorf("bounds of banana = %d, %d", lo, hi)
+	}
+
+	small := []int8{-128, -100, 0, 100, 127}
+	for i, x := range small {
+		if got, ok := InterpolationSearch(small, x); got != i || !ok {
+			t.Errorf("InterpolationSearch(int8s, %d) = %d, %t", x, got, ok)
+		}
+	}
+	floats := []float64{math.Inf(-1), -1.5, 0, 2.25, math.Inf(1)}
+	for i, x := range floats {
+		if got, ok := InterpolationSearch(floats, x); got != i || !ok {
+			t.Errorf("InterpolationSearch(floats, %v) = %d, %t", x, got, ok)
+		}
+	}
+	if got, ok := InterpolationSearch(floats, 1); got != 3 || ok {
+		t.Errorf("InterpolationSearch(floats, 1) = %d, %t", got, ok)
+	}
+}
+
+func TestMergeSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 300 {
+		s := make([]float64, r.IntN(200))
+		for i := range s {
+			switch r.IntN(20) {
+			case 0:
+				s[i] = math.NaN()
+			case 1:
+				s[i] = math.Copysign(0, -1)
+			default:
+				s[i] = float64(r.IntN(50))
+			}
+		}
+		got, want := slices.Clone(s), slices.Clone(s)
+		MergeSort(got)
+		slices.SortStableFunc(want, cmp.Compare[float64])
+		for i := range want {
+			if math.Float64bits(got[i]) != math.Float64bits(want[i]) {
+				t.Fatalf("MergeSort(%v) = %v, want %v", s, got, want)
+			}
+		}
+	}
+}
+
+func TestMergeSortFuncStable(t *testing.T) {
+	type rec struct{ key, seq int }
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 300 {
+		s := make([]rec, r.IntN(200))
+		for i := range s {
+			s[i] = rec{r.IntN(10), i}
+		}
+		byKey := func(a, b rec) int { return cmp.Compare(a.key, b.key) }
+		got, want := slices.Clone(s), slices.Clone(s)
+		MergeSortFunc(got, byKey)
+		slices.SortStableFunc(want, byKey)
+		if !slices.Equal(got, want) {
+			t.Fatalf("MergeSortFunc is not stable:\n got %v\nwant %v", got, want)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 300 {
+		s := make([]int, 1+r.IntN(100))
+		for i := range s {
+			s[i] = r.IntN(1 + r.IntN(30))
+		}
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		k := r.IntN(len(s))
+		work := slices.Clone(s)
+		if got := Select(work, k); got != sorted[k] {
+			t.Fatalf("Select(%v, %d) = %d, want %d", s, k, got, sorted[k])
+		}
+		if slices.Max(work[:k+1]) != work[k] || slices.Min(work[k:]) != work[k] {
+			t.Fatalf("Select(%v, %d) left %v unpartitioned", s, k, work)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Select out of range did not panic")
+		}
+	}()
+	Select([]int{1}, 1)
+}
+
+func BenchmarkSearch(b *testing.B) {
+	s := make([]int, 1<<20)
+	for i := range s {
+		s[i] = 3 * i
+	}
+	searches := []struct {
+		name   string
+		search func([]int, int) (int, bool)
+	}{
+		{"Binary", Search[[]int]},
+		{"Exponential", ExponentialSearch[[]int]},
+		{"Interpolation", InterpolationSearch[[]int]},
+		{"slices.BinarySearch", slices.BinarySearch[[]int]},
+	}
+	for _, sr := range searches {
+		b.Run(sr.name, func(b *testing.B) {
+			for i := range b.N {
+				sr.search(s, (i*7919)%(3*len(s)))
+			}
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	r := rand.New(rand.NewPCG(9, 10))
+	data := make([]int, 10_000)
+	for i := range data {
+		data[i] = r.Int()
+	}
+	s := make([]int, len(data))
+	b.Run("MergeSort", func(b *testing.B) {
+		for range b.N {
+			copy(s, data)
+			MergeSort(s)
+		}
+	})
+	b.Run("slices.SortStable", func(b *testing.B) {
+		for range b.N {
+			copy(s, data)
+			slices.SortStableFunc(s, cmp.Compare[int])
+		}
+	})
+	b.Run("Select", func(b *testing.B) {
+		for range b.N {
+			copy(s, data)
+			Select(s, len(s)/2)
+		}
+	})
+	b.Run("slices.Sort", func(b *testing.B) {
+		for range b.N {
+			copy(s, data)
+			slices.Sort(s)
+		}
+	})
+}
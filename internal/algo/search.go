@@ -0,0 +1,110 @@
+// Package algo has generic searching and sorting algorithms for slices of
+// any ordered type.
+//
+// It grows out of the functions chapter's binarySearch, which works only on
+// []int and reports only whether the target is there, and the control
+// structures chapter's findNumber, a linear scan. The searches here return
+// indices, as slices.BinarySearch does; the sorts and selection work in
+// place. The tests check each one against slices.Sort and
+// slices.BinarySearch on random input.
+//
+// Values are compared with cmp.Compare, so a NaN sorts before every other
+// float, as in the slices package.
+package algo
+
+import "cmp"
+
+// LowerBound returns the index of the first element of the sorted slice s
+// that is not less than x, or len(s) if there is none. It is where x would
+// be inserted to keep s sorted, before any elements equal to it.
+func LowerBound[S ~[]E, E cmp.Ordered](s S, x E) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp.Less(s[mid], x) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first element of the sorted slice s
+// that is greater than x, or len(s) if there is none. s[LowerBound(s, x):
+// UpperBound(s, x)] are the elements equal to x.
+func UpperBound[S ~[]E, E cmp.Ordered](s S, x E) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp.Less(x, s[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// Search finds x in the sorted slice s by binary search. It returns the
+// index of the first element equal to x and true, or the index x would be
+// inserted at and false, like slices.BinarySearch.
+func Search[S ~[]E, E cmp.Ordered](s S, x E) (int, bool) {
+	i := LowerBound(s, x)
+	return i, i < len(s) && cmp.Compare(s[i], x) == 0
+}
+
+// ExponentialSearch is like Search, but first doubles a bound from the
+// start of s until it passes x, and then searches below it. Its cost
+// grows with the position of x rather than the length of s, which suits
+// very long slices where x is near the front.
+func ExponentialSearch[S ~[]E, E cmp.Ordered](s S, x E) (int, bool) {
+	bound := 1
+	for bound < len(s) && cmp.Less(s[bound], x) {
+		bound *= 2
+	}
+	lo := bound / 2
+	i := lo + LowerBound(s[lo:min(bound+1, len(s))], x)
+	return i, i < len(s) && cmp.Compare(s[i], x) == 0
+}
+
+// Number is the constraint for InterpolationSearch, which needs to do
+// arithmetic on the elements.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// InterpolationSearch is like Search, but guesses where x lies from its
+// value, as one opens a dictionary near the back for a word starting with
+// "w". On evenly spread numbers it takes about log log n steps; on badly
+// skewed ones it can take n. s must not contain NaNs.
+func InterpolationSearch[S ~[]E, E Number](s S, x E) (int, bool) {
+	// Every element before lo is less than x; every one from hi on is not.
+	lo, hi := 0, len(s)
+	for lo < hi {
+		first, last := s[lo], s[hi-1]
+		if x <= first {
+			hi = lo
+			break
+		}
+		if last < x {
+			lo = hi
+			break
+		}
+		// Now first < x <= last, so the answer is in (lo, hi-1].
+		// Subtract as floats, since x-first can overflow a small integer type.
+		frac := (float64(x) - float64(first)) / (float64(last) - float64(first))
+		if !(frac >= 0) { // NaN, from infinities
+			frac = 0.5
+		}
+		p := lo + int(min(frac, 1)*float64(hi-1-lo))
+		if s[p] < x {
+			lo = p + 1
+		} else {
+			hi = p
+		}
+	}
+	return lo, lo < len(s) && s[lo] == x
+}
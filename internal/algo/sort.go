@@ -0,0 +1,109 @@
+package algo
+
+import (
+	"cmp"
+	"math/rand/v2"
+)
+
+// MergeSort sorts s in increasing order. Equal elements keep their order,
+// which for ordered values only matters for floats' -0 and +0.
+func MergeSort[S ~[]E, E cmp.Ordered](s S) {
+	MergeSortFunc(s, cmp.Compare[E])
+}
+
+// MergeSortFunc sorts s in the order given by cmp, which returns a negative
+// number when a < b, a positive one when a > b and zero when they are
+// equal. The sort is stable: equal elements keep their original order. It
+// takes O(n log n) time and a buffer the size of s.
+func MergeSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	if len(s) < 2 {
+		return
+	}
+	mergeSort(s, make(S, len(s)), cmp)
+}
+
+// insertionCutoff is the length below which mergeSort uses insertion sort,
+// which is faster on short runs.
+const insertionCutoff = 12
+
+func mergeSort[S ~[]E, E any](s, buf S, cmp func(a, b E) int) {
+	if len(s) <= insertionCutoff {
+		insertionSort(s, cmp)
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], buf[:mid], cmp)
+	mergeSort(s[mid:], buf[mid:], cmp)
+	if cmp(s[mid-1], s[mid]) <= 0 {
+		return // already in order
+	}
+
+	// Merge from a copy of the left half. Taking from the left on ties
+	// keeps the sort stable. If the left half runs out first, the rest of
+	// the right half is already in place.
+	left := buf[:mid]
+	copy(left, s[:mid])
+	i, j, k := 0, mid, 0
+	for i < len(left) && j < len(s) {
+		if cmp(s[j], left[i]) < 0 {
+			s[k] = s[j]
+			j++
+		} else {
+			s[k] = left[i]
+			i++
+		}
+		k++
+	}
+	copy(s[k:], left[i:])
+}
+
+func insertionSort[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	for i := 1; i < len(s); i++ {
+		x := s[i]
+		j := i
+		for ; j > 0 && cmp(s[j-1], x) > 0; j-- {
+			s[j] = s[j-1]
+		}
+		s[j] = x
+	}
+}
+
+// Select returns the kth smallest element of s, counting from 0, so that
+// Select(s, 0) is the minimum and Select(s, len(s)/2) a median. It uses
+// quickselect, which takes O(n) time on average, and reorders s as it
+// goes: afterwards s[k] holds the result, with no greater element before
+// it and no smaller one after. Select panics if k is out of range.
+func Select[S ~[]E, E cmp.Ordered](s S, k int) E {
+	if k < 0 || k >= len(s) {
+		panic("algo: Select index out of range")
+	}
+	lo, hi := 0, len(s) // k is in s[lo:hi]
+	for hi-lo > 1 {
+		// Partition s[lo:hi] three ways around a random pivot:
+		// s[lo:lt] < pivot, s[lt:gt] == pivot, s[gt:hi] > pivot.
+		pivot := s[lo+rand.IntN(hi-lo)]
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			switch c := cmp.Compare(s[i], pivot); {
+			case c < 0:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case c > 0:
+				gt--
+				s[gt], s[i] = s[i], s[gt]
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return s[k]
+		}
+	}
+	return s[k]
+}
@@ -0,0 +1,224 @@
+// Package bands classifies values by the range they fall in, such as ages
+// into "Child", "Teenager" and "Adult", order totals into pricing tiers or
+// response times into SLA buckets.
+//
+// The control structures chapter writes such rules as an if/else ladder or
+// a switch. Here they are data instead: a list of bands, each a range and
+// a label, given in code or read from JSON. New checks when the list is
+// built that the bands neither overlap nor leave gaps, and Lookup finds
+// the band for a value by binary search.
+package bands
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Band labels the values from Min up to, but not including, Max. A band
+// with NoMin has no lower bound and one with NoMax no upper bound; Min or
+// Max is then ignored.
+//
+// In JSON a band is an object such as {"min": 13, "max": 20, "label":
+// "Teenager"}, and leaving out "min" or "max" leaves that end open.
+type Band[K cmp.Ordered, L any] struct {
+	Min, Max     K
+	NoMin, NoMax bool
+	Label        L
+}
+
+// Range returns the band from min up to, but not including, max.
+func Range[K cmp.Ordered, L any](min, max K, label L) Band[K, L] {
+	return Band[K, L]{Min: min, Max: max, Label: label}
+}
+
+// From returns the band of min and everything above it.
+func From[K cmp.Ordered, L any](min K, label L) Band[K, L] {
+	return Band[K, L]{Min: min, NoMax: true, Label: label}
+}
+
+// Below returns the band of everything below max.
+func Below[K cmp.Ordered, L any](max K, label L) Band[K, L] {
+	return Band[K, L]{Max: max, NoMin: true, Label: label}
+}
+
+// Contains reports whether v is in the band.
+func (b Band[K, L]) Contains(v K) bool {
+	return (b.NoMin || cmp.Compare(v, b.Min) >= 0) && (b.NoMax || cmp.Compare(v, b.Max) < 0)
+}
+
+// String returns the band's range, such as "[13, 20)" or "[65, ∞)".
+func (b Band[K, L]) String() string {
+	lo, hi := "(-∞", "∞)"
+	if !b.NoMin {
+		lo = fmt.Sprintf("[%v", b.Min)
+	}
+	if !b.NoMax {
+		hi = fmt.Sprintf("%v)", b.Max)
+	}
+	return lo + ", " + hi
+}
+
+type jsonBand[K cmp.Ordered, L any] struct {
+	Min   *K `json:"min,omitempty"`
+	Max   *K `json:"max,omitempty"`
+	Label L  `json:"label"`
+}
+
+func (b Band[K, L]) MarshalJSON() ([]byte, error) {
+	j := jsonBand[K, L]{Label: b.Label}
+	if !b.NoMin {
+		j.Min = &b.Min
+	}
+	if !b.NoMax {
+		j.Max = &b.Max
+	}
+	return json.Marshal(j)
+}
+
+func (b *Band[K, L]) UnmarshalJSON(data []byte) error {
+	var j jsonBand[K, L]
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*b = Band[K, L]{NoMin: j.Min == nil, NoMax: j.Max == nil, Label: j.Label}
+	if j.Min != nil {
+		b.Min = *j.Min
+	}
+	if j.Max != nil {
+		b.Max = *j.Max
+	}
+	return nil
+}
+
+// Classifier finds the band a value falls in. Build one with New, Must or
+// LoadFile, or by unmarshalling a JSON array of bands into it. The zero
+// Classifier has no bands.
+type Classifier[K cmp.Ordered, L any] struct {
+	bands []Band[K, L] // in order, without gaps or overlaps
+}
+
+// New returns a Classifier for the bands, which may be given in any order.
+// It is an error for a band to be empty, for two bands to overlap, or for
+// there to be a gap between bands; the error lists every such problem.
+// Values below the lowest band or above the highest have no band, unless
+// those bands are open.
+func New[K cmp.Ordered, L any](bands ...Band[K, L]) (*Classifier[K, L], error) {
+	sorted := slices.Clone(bands)
+	slices.SortStableFunc(sorted, func(a, b Band[K, L]) int {
+		switch {
+		case a.NoMin && b.NoMin:
+			return 0
+		case a.NoMin:
+			return -1
+		case b.NoMin:
+			return 1
+		}
+		return cmp.Compare(a.Min, b.Min)
+	})
+
+	var errs []error
+	for i, b := range sorted {
+		if (!b.NoMin && isNaN(b.Min)) || (!b.NoMax && isNaN(b.Max)) {
+			errs = append(errs, fmt.Errorf("bands: %v band %s has a NaN bound", b.Label, b))
+			continue
+		}
+		if !b.NoMin && !b.NoMax && cmp.Compare(b.Min, b.Max) >= 0 {
+			errs = append(errs, fmt.Errorf("bands: %v band %s is empty", b.Label, b))
+		}
+		if i == 0 {
+			continue
+		}
+		prev := sorted[i-1]
+		switch {
+		case prev.NoMax || b.NoMin || cmp.Compare(b.Min, prev.Max) < 0:
+			errs = append(errs, fmt.Errorf("bands: %v band %s overlaps %v band %s", prev.Label, prev, b.Label, b))
+		case cmp.Compare(b.Min, prev.Max) > 0:
+			errs = append(errs, fmt.Errorf("bands: gap between %v band %s and %v band %s", prev.Label, prev, b.Label, b))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &Classifier[K, L]{bands: sorted}, nil
+}
+
+// Must is like New but panics if the bands are not valid. It is meant for
+// bands fixed in code, as regexp.MustCompile is for patterns.
+func Must[K cmp.Ordered, L any](bands ...Band[K, L]) *Classifier[K, L] {
+	c, err := New(bands...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// LoadFile reads a JSON array of bands from the named file and returns a
+// Classifier for them.
+func LoadFile[K cmp.Ordered, L any](name string) (*Classifier[K, L], error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c Classifier[K, L]
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &c, nil
+}
+
+// Lookup returns the label of the band v falls in. ok is false if v is in
+// no band.
+func (c *Classifier[K, L]) Lookup(v K) (label L, ok bool) {
+	b, ok := c.Band(v)
+	return b.Label, ok
+}
+
+// Band returns the band v falls in. ok is false if v is in no band.
+func (c *Classifier[K, L]) Band(v K) (b Band[K, L], ok bool) {
+	if isNaN(v) {
+		return b, false
+	}
+	// Find the first band that starts above v; v can only be in the one
+	// before it.
+	i, _ := slices.BinarySearchFunc(c.bands, v, func(b Band[K, L], v K) int {
+		if b.NoMin || cmp.Compare(b.Min, v) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	if i == 0 || !c.bands[i-1].Contains(v) {
+		return b, false
+	}
+	return c.bands[i-1], true
+}
+
+// Bands returns the bands in order.
+func (c *Classifier[K, L]) Bands() []Band[K, L] { return slices.Clone(c.bands) }
+
+func (c *Classifier[K, L]) MarshalJSON() ([]byte, error) {
+	if c.bands == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.bands)
+}
+
+// UnmarshalJSON reads a JSON array of bands, checking them as New does.
+func (c *Classifier[K, L]) UnmarshalJSON(data []byte) error {
+	var bands []Band[K, L]
+	if err := json.Unmarshal(data, &bands); err != nil {
+		return err
+	}
+	n, err := New(bands...)
+	if err != nil {
+		return err
+	}
+	*c = *n
+	return nil
+}
+
+// isNaN reports whether v is a floating-point NaN, which falls in no band.
+func isNaN[K cmp.Ordered](v K) bool { return v != v }
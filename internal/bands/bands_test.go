@@ -0,0 +1,154 @@
+package bands
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var ages = Must(
+	From(65, "Senior"),
+	Below(0, "Invalid"),
+	Range(13, 20, "Teenager"),
+	Range(0, 13, "Child"),
+	Range(20, 65, "Adult"),
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		age  int
+		want string
+	}{
+		{math.MinInt, "Invalid"},
+		{-1, "Invalid"},
+		{0, "Child"},
+		{12, "Child"},
+		{13, "Teenager"},
+		{19, "Teenager"},
+		{20, "Adult"},
+		{64, "Adult"},
+		{65, "Senior"},
+		{math.MaxInt, "Senior"},
+	}
+	for _, tt := range tests {
+		if got, ok := ages.Lookup(tt.age); !ok || got != tt.want {
+			t.Errorf("Lookup(%d) = %q, %t; want %q", tt.age, got, ok, tt.want)
+		}
+	}
+
+	var labels []string
+	for _, b := range ages.Bands() {
+		labels = append(labels, b.Label)
+	}
+	if got := strings.Join(labels, ","); got != "Invalid,Child,Teenager,Adult,Senior" {
+		t.Errorf("Bands in order %s", got)
+	}
+}
+
+func TestLookupClosed(t *testing.T) {
+	tiers := Must(Range(0.0, 100, "standard"), Range(100.0, 1000, "silver"), Range(1000.0, 5000, "gold"))
+	tests := []struct {
+		total float64
+		want  string
+		ok    bool
+	}{
+		{-0.01, "", false},
+		{0, "standard", true},
+		{99.99, "standard", true},
+		{100, "silver", true},
+		{4999.99, "gold", true},
+		{5000, "", false},
+		{math.Inf(1), "", false},
+		{math.NaN(), "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := tiers.Lookup(tt.total); ok != tt.ok || got != tt.want {
+			t.Errorf("Lookup(%v) = %q, %t; want %q, %t", tt.total, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	var empty Classifier[int, string]
+	if _, ok := empty.Lookup(1); ok {
+		t.Error("zero Classifier found a band")
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		bands []Band[float64, string]
+		want  []string
+	}{
+		{"gap", []Band[float64, string]{Range(0.0, 10, "a"), Range(11.0, 20, "b")},
+			[]string{"gap between a band [0, 10) and b band [11, 20)"}},
+		{"overlap", []Band[float64, string]{Range(0.0, 10, "a"), Range(5.0, 20, "b")},
+			[]string{"a band [0, 10) overlaps b band [5, 20)"}},
+		{"open overlap", []Band[float64, string]{From(0.0, "a"), Range(5.0, 20, "b")},
+			[]string{"a band [0, ∞) overlaps b band [5, 20)"}},
+		{"two open bottoms", []Band[float64, string]{Below(0.0, "a"), Below(5.0, "b")},
+			[]string{"a band (-∞, 0) overlaps b band (-∞, 5)"}},
+		{"empty", []Band[float64, string]{Range(5.0, 5, "a")},
+			[]string{"a band [5, 5) is empty"}},
+		{"nan", []Band[float64, string]{Range(math.NaN(), 5, "a")},
+			[]string{"a band [NaN, 5) has a NaN bound"}},
+		{"several", []Band[float64, string]{Range(0.0, 10, "a"), Range(12.0, 11, "b"), Range(20.0, 30, "c")},
+			[]string{"gap between a", "b band [12, 11) is empty", "gap between b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.bands...)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Must did not panic on bad bands")
+		}
+	}()
+	Must(Range(0, 10, "a"), Range(5, 20, "b"))
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(ages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"max":0,"label":"Invalid"},{"min":0,"max":13,"label":"Child"},{"min":13,"max":20,"label":"Teenager"},{"min":20,"max":65,"label":"Adult"},{"min":65,"label":"Senior"}]`
+	if string(data) != want {
+		t.Errorf("Marshal = %s\nwant %s", data, want)
+	}
+
+	file := filepath.Join(t.TempDir(), "ages.json")
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadFile[int, string](file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Lookup(-3); !ok || got != "Invalid" {
+		t.Errorf("loaded classifier gives %q, %t for -3", got, ok)
+	}
+
+	var sla struct {
+		Buckets Classifier[float64, string] `json:"buckets"`
+	}
+	err = json.Unmarshal([]byte(`{"buckets": [{"max": 100, "label": "fast"}, {"min": 200, "label": "slow"}]}`), &sla)
+	if err == nil || !strings.Contains(err.Error(), "gap between fast band (-∞, 100) and slow band [200, ∞)") {
+		t.Errorf("Unmarshal with a gap: %v", err)
+	}
+	if _, err := LoadFile[int, string](filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadFile of a missing file succeeded")
+	}
+}
@@ -0,0 +1,146 @@
+// Package bigmath computes factorials and Fibonacci numbers of any size
+// with math/big, in the several ways the functions chapter's recursive
+// factorial and fibonacci lead on to.
+//
+// The chapter's factorial overflows an int past 20!, and a plain recursive
+// Fibonacci makes an exponential number of calls. Here every result is a
+// *big.Int, Fibonacci comes in naive, iterative, memoized and
+// fast-doubling versions, and the Context variants stop early when their
+// context is cancelled. The benchmarks in the tests compare them.
+//
+// All functions panic if n is negative, as math/big does for bad
+// arguments. Each call returns a new *big.Int that the caller may modify.
+package bigmath
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+func checkN(n int) {
+	if n < 0 {
+		panic("bigmath: negative n")
+	}
+}
+
+// Factorial returns n!.
+func Factorial(n int) *big.Int {
+	checkN(n)
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// factorialChunk is how many factors FactorialContext multiplies between
+// checks of its context.
+const factorialChunk = 512
+
+// FactorialContext returns n!, or the context's error if it is cancelled
+// first.
+func FactorialContext(ctx context.Context, n int) (*big.Int, error) {
+	checkN(n)
+	result := big.NewInt(1)
+	var part big.Int
+	for lo := int64(1); lo <= int64(n); lo += factorialChunk {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hi := min(lo+factorialChunk-1, int64(n))
+		result.Mul(result, part.MulRange(lo, hi))
+	}
+	return result, nil
+}
+
+// FibNaive returns the nth Fibonacci number by the textbook recursion,
+// making about 1.6ⁿ calls. It is here to be compared against; it overflows
+// past n = 93.
+func FibNaive(n int) uint64 {
+	checkN(n)
+	if n < 2 {
+		return uint64(n)
+	}
+	return FibNaive(n-1) + FibNaive(n-2)
+}
+
+// FibIterative returns the nth Fibonacci number, adding up from the start
+// in n steps.
+func FibIterative(n int) *big.Int {
+	checkN(n)
+	a, b := big.NewInt(0), big.NewInt(1)
+	for range n {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return a
+}
+
+// fibMemo holds every Fibonacci number FibMemo has computed so far.
+var fibMemo struct {
+	sync.Mutex
+	nums []*big.Int
+}
+
+// FibMemo returns the nth Fibonacci number, remembering every number it
+// computes on the way, so that later calls for the same or smaller n only
+// copy the answer and larger n continue from where the last call stopped.
+// F(n) has about 0.7n bits, so the memory used grows with the square of
+// the largest n asked for: keep n to the thousands.
+func FibMemo(n int) *big.Int {
+	checkN(n)
+	fibMemo.Lock()
+	defer fibMemo.Unlock()
+	if fibMemo.nums == nil {
+		fibMemo.nums = []*big.Int{big.NewInt(0), big.NewInt(1)}
+	}
+	for i := len(fibMemo.nums); i <= n; i++ {
+		fibMemo.nums = append(fibMemo.nums, new(big.Int).Add(fibMemo.nums[i-1], fibMemo.nums[i-2]))
+	}
+	return new(big.Int).Set(fibMemo.nums[n])
+}
+
+// FibFastDoubling returns the nth Fibonacci number using
+//
+//	F(2k)   = F(k) × (2F(k+1) − F(k))
+//	F(2k+1) = F(k)² + F(k+1)²
+//
+// which takes about log₂ n steps instead of n.
+func FibFastDoubling(n int) *big.Int {
+	f, _ := fibDoubling(context.Background(), n)
+	return f
+}
+
+// FibContext returns the nth Fibonacci number as FibFastDoubling does, or
+// the context's error if it is cancelled first.
+func FibContext(ctx context.Context, n int) (*big.Int, error) {
+	return fibDoubling(ctx, n)
+}
+
+func fibDoubling(ctx context.Context, n int) (*big.Int, error) {
+	checkN(n)
+	// a, b = F(k), F(k+1), for k made of n's leading bits.
+	a, b := big.NewInt(0), big.NewInt(1)
+	var c, d, t big.Int
+	for bit := highBit(n); bit > 0; bit >>= 1 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.Mul(a, t.Sub(t.Lsh(b, 1), a)) // F(2k)
+		d.Add(a.Mul(a, a), t.Mul(b, b)) // F(2k+1)
+		if n&bit == 0 {
+			a.Set(&c)
+			b.Set(&d)
+		} else {
+			a.Set(&d)
+			b.Add(&c, &d)
+		}
+	}
+	return a, nil
+}
+
+// highBit returns the highest set bit of n, or 0 if n is 0.
+func highBit(n int) int {
+	bit := 1
+	for bit <= n>>1 {
+		bit <<= 1
+	}
+	return bit & n
+}
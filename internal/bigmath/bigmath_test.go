@@ -0,0 +1,148 @@
+package bigmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFactorial(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "1"},
+		{1, "1"},
+		{5, "120"},
+		{20, "2432902008176640000"},
+		{25, "15511210043330985984000000"},
+	}
+	for _, tt := range tests {
+		if got := Factorial(tt.n).String(); got != tt.want {
+			t.Errorf("Factorial(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+
+	for _, n := range []int{0, 511, 512, 513, 2000} {
+		got, err := FactorialContext(context.Background(), n)
+		if err != nil || got.Cmp(Factorial(n)) != 0 {
+			t.Errorf("FactorialContext(%d) differs from Factorial: %v", n, err)
+		}
+	}
+}
+
+func TestFib(t *testing.T) {
+	if got := FibIterative(100).String(); got != "354224848179261915075" {
+		t.Errorf("FibIterative(100) = %s", got)
+	}
+	for n := range 300 {
+		want := FibIterative(n)
+		if n <= 30 {
+			if got := FibNaive(n); new(big.Int).SetUint64(got).Cmp(want) != 0 {
+				t.Errorf("FibNaive(%d) = %d, want %s", n, got, want)
+			}
+		}
+		if got := FibMemo(n); got.Cmp(want) != 0 {
+			t.Errorf("FibMemo(%d) = %s, want %s", n, got, want)
+		}
+		if got := FibFastDoubling(n); got.Cmp(want) != 0 {
+			t.Errorf("FibFastDoubling(%d) = %s, want %s", n, got, want)
+		}
+	}
+
+	// Callers own the result, so changing it must not change the memo.
+	FibMemo(10).SetInt64(-1)
+	if got := FibMemo(10).Int64(); got != 55 {
+		t.Errorf("FibMemo(10) = %d after changing an earlier result", got)
+	}
+}
+
+func TestNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Factorial(-1) did not panic")
+		}
+	}()
+	Factorial(-1)
+}
+
+func TestContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FactorialContext(ctx, 100_000); !errors.Is(err, context.Canceled) {
+		t.Errorf("FactorialContext error = %v", err)
+	}
+	if _, err := FibContext(ctx, 1_000_000); !errors.Is(err, context.Canceled) {
+		t.Errorf("FibContext error = %v", err)
+	}
+	// F(0) takes no steps, so there is no point at which to look at the
+	// context; F(1) already takes one, and checks before it.
+	if f, err := FibContext(ctx, 0); err != nil || f.Sign() != 0 {
+		t.Errorf("FibContext(0) = %v, %v", f, err)
+	}
+	if _, err := FibContext(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("FibContext(1) error = %v", err)
+	}
+}
+
+func BenchmarkFib(b *testing.B) {
+	variants := []struct {
+		name string
+		fib  func(int) *big.Int
+	}{
+		{"Iterative", FibIterative},
+		// A fresh memo each time, so that every call computes F(n).
+		{"Memo", func(n int) *big.Int {
+			resetFibMemo()
+			return FibMemo(n)
+		}},
+		// The memo already holds F(n), as it does in a program that asks
+		// for the same numbers again: each call only copies the answer.
+		{"MemoWarm", FibMemo},
+		{"FastDoubling", FibFastDoubling},
+	}
+	for _, n := range []int{30, 1000, 100_000} {
+		if n == 30 {
+			b.Run(fmt.Sprintf("Naive/%d", n), func(b *testing.B) {
+				for range b.N {
+					FibNaive(n)
+				}
+			})
+		}
+		for _, v := range variants {
+			if strings.HasPrefix(v.name, "Memo") && n > 1000 {
+				continue // the memo would hold hundreds of megabytes
+			}
+			b.Run(fmt.Sprintf("%s/%d", v.name, n), func(b *testing.B) {
+				for range b.N {
+					v.fib(n)
+				}
+			})
+		}
+	}
+}
+
+// resetFibMemo makes FibMemo forget every number it has computed.
+func resetFibMemo() {
+	fibMemo.Lock()
+	defer fibMemo.Unlock()
+	fibMemo.nums = nil
+}
+
+func BenchmarkFactorial(b *testing.B) {
+	for _, n := range []int{20, 1000, 20_000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for range b.N {
+				Factorial(n)
+			}
+		})
+		b.Run(fmt.Sprintf("Context/%d", n), func(b *testing.B) {
+			for range b.N {
+				FactorialContext(context.Background(), n)
+			}
+		})
+	}
+}
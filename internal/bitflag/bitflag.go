@@ -0,0 +1,133 @@
+// Package bitflag keeps a set of flags in the bits of an integer, as the
+// FlagRead, FlagWrite and FlagExecute constants of the data types chapter
+// do, and gives such sets the operations they always need: testing,
+// setting, clearing and toggling flags, and writing and parsing them as
+// "read|write".
+//
+// A flag type is an unsigned integer type whose constants are single bits
+// and whose String method names them:
+//
+//	type Color uint8
+//
+//	const (
+//		Red Color = 1 << iota
+//		Green
+//		Blue
+//	)
+//
+//	func (c Color) String() string { ... }
+//
+// Set[Color] is then a set of colors. Perm is a flag type for Unix
+// permissions, and Mode converts them to and from an fs.FileMode.
+package bitflag
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// Flag is the constraint on flag types.
+type Flag interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint
+	String() string
+}
+
+// Set is a set of flags of type F. The zero value is the empty set. Sets
+// are values: Set, Clear and Toggle return a new set.
+type Set[F Flag] struct {
+	bits F
+}
+
+// Of returns the set holding flags.
+func Of[F Flag](flags ...F) Set[F] {
+	var s Set[F]
+	for _, f := range flags {
+		s.bits |= f
+	}
+	return s
+}
+
+// Bits returns the set as an integer of the flag type.
+func (s Set[F]) Bits() F { return s.bits }
+
+// Empty reports whether the set holds no flags.
+func (s Set[F]) Empty() bool { return s.bits == 0 }
+
+// Has reports whether the set holds f. If f has several bits set, as
+// Read|Write does, Has reports whether it holds all of them.
+func (s Set[F]) Has(f F) bool { return s.bits&f == f }
+
+// Set returns s with f added.
+func (s Set[F]) Set(f F) Set[F] { return Set[F]{s.bits | f} }
+
+// Clear returns s with f removed.
+func (s Set[F]) Clear(f F) Set[F] { return Set[F]{s.bits &^ f} }
+
+// Toggle returns s with f removed if it was there, and added if not.
+func (s Set[F]) Toggle(f F) Set[F] { return Set[F]{s.bits ^ f} }
+
+// All yields the flags in the set, lowest bit first.
+func (s Set[F]) All() iter.Seq[F] {
+	return func(yield func(F) bool) {
+		for b := uint64(s.bits); b != 0; b &= b - 1 {
+			if !yield(F(1) << bits.TrailingZeros64(b)) {
+				return
+			}
+		}
+	}
+}
+
+// String returns the names of the flags in the set, lowest bit first,
+// joined by "|", as in "read|write". The empty set is "0".
+func (s Set[F]) String() string {
+	if s.bits == 0 {
+		return "0"
+	}
+	var names []string
+	for f := range s.All() {
+		names = append(names, f.String())
+	}
+	return strings.Join(names, "|")
+}
+
+// Parse parses a set written as String writes it. The names are those the
+// flag type's String method gives its bits; spaces around them are
+// ignored. "0" and "" are the empty set.
+func Parse[F Flag](s string) (Set[F], error) {
+	var set Set[F]
+	if s = strings.TrimSpace(s); s == "" || s == "0" {
+		return set, nil
+	}
+	for name := range strings.SplitSeq(s, "|") {
+		name = strings.TrimSpace(name)
+		f, ok := lookup[F](name)
+		if !ok {
+			return Set[F]{}, fmt.Errorf("bitflag: unknown flag %q in %q", name, s)
+		}
+		set.bits |= f
+	}
+	return set, nil
+}
+
+// lookup finds the bit of F named name.
+func lookup[F Flag](name string) (F, bool) {
+	// The loop ends when the bit is shifted out of F.
+	for f := F(1); f != 0; f <<= 1 {
+		if f.String() == name {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func (s Set[F]) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *Set[F]) UnmarshalText(text []byte) error {
+	v, err := Parse[F](string(text))
+	if err == nil {
+		*s = v
+	}
+	return err
+}
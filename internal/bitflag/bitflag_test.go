@@ -0,0 +1,137 @@
+package bitflag
+
+import (
+	"encoding/json"
+	"io/fs"
+	"slices"
+	"testing"
+)
+
+type color uint16
+
+const (
+	red color = 1 << iota
+	green
+	blue
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	_
+	ultraviolet // the top bit
+)
+
+func (c color) String() string {
+	switch c {
+	case red:
+		return "red"
+	case green:
+		return "green"
+	case blue:
+		return "blue"
+	case ultraviolet:
+		return "ultraviolet"
+	}
+	return "color?"
+}
+
+func TestSet(t *testing.T) {
+	var s Set[color]
+	if !s.Empty() || s.String() != "0" {
+		t.Errorf("zero set is %q", s)
+	}
+	s = s.Set(blue).Set(red)
+	if !s.Has(red) || !s.Has(blue) || s.Has(green) || !s.Has(red|blue) || s.Has(red|green) {
+		t.Errorf("Has is wrong for %v", s)
+	}
+	if got := s.String(); got != "red|blue" {
+		t.Errorf("String() = %q, want red|blue", got)
+	}
+	if got := s.Toggle(red | green).String(); got != "green|blue" {
+		t.Errorf("Toggle gave %q", got)
+	}
+	if got := s.Clear(blue).Clear(green); got != Of(red) {
+		t.Errorf("Clear gave %v", got)
+	}
+	if s.Bits() != red|blue {
+		t.Errorf("Bits() = %d", s.Bits())
+	}
+	if got := slices.Collect(Of(ultraviolet, green).All()); !slices.Equal(got, []color{green, ultraviolet}) {
+		t.Errorf("All() = %v", got)
+	}
+	if got := Of[color](8).String(); got != "color?" {
+		t.Errorf("unnamed bit: %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]Set[color]{
+		"red|blue":          Of(red, blue),
+		" blue | red ":      Of(red, blue),
+		"ultraviolet":       Of(ultraviolet),
+		"red|red":           Of(red),
+		"0":                 {},
+		"":                  {},
+		"red|green|blue":    Of(red, green, blue),
+		"green|ultraviolet": Of(green, ultraviolet),
+	} {
+		got, err := Parse[color](in)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"purple", "red|", "red,blue", "RED"} {
+		if got, err := Parse[color](in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, got)
+		}
+	}
+
+	var cfg struct{ Paint Set[color] }
+	if err := json.Unmarshal([]byte(`{"Paint":"green|blue"}`), &cfg); err != nil || cfg.Paint != Of(green, blue) {
+		t.Fatalf("Unmarshal gave %v, %v", cfg.Paint, err)
+	}
+	if data, _ := json.Marshal(cfg); string(data) != `{"Paint":"green|blue"}` {
+		t.Errorf("Marshal gave %s", data)
+	}
+}
+
+func TestMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode                fs.FileMode
+		owner, group, other string
+		ls                  string
+	}{
+		{0644, "read|write", "read", "read", "rw-r--r--"},
+		{0755, "read|write|execute", "read|execute", "read|execute", "rwxr-xr-x"},
+		{0600, "read|write", "0", "0", "rw-------"},
+		{fs.ModeDir | 0750, "read|write|execute", "read|execute", "0", "rwxr-x---"},
+	} {
+		m := ModeOf(tt.mode)
+		if m.Owner.String() != tt.owner || m.Group.String() != tt.group || m.Other.String() != tt.other {
+			t.Errorf("ModeOf(%o) = %v, %v, %v", tt.mode, m.Owner, m.Group, m.Other)
+		}
+		if m.String() != tt.ls {
+			t.Errorf("ModeOf(%o).String() = %q, want %q", tt.mode, m, tt.ls)
+		}
+		if m.FileMode() != tt.mode.Perm() {
+			t.Errorf("ModeOf(%o).FileMode() = %o", tt.mode, m.FileMode())
+		}
+	}
+
+	m := Mode{Owner: Of(Read, Write), Group: Of(Read), Other: Of(Read)}
+	if m.FileMode() != 0644 {
+		t.Errorf("FileMode() = %o, want 644", m.FileMode())
+	}
+	m.Other = m.Other.Clear(Read)
+	m.Group = m.Group.Toggle(Write)
+	if m.FileMode() != 0660 {
+		t.Errorf("FileMode() = %o, want 660", m.FileMode())
+	}
+}
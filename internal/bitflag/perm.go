@@ -0,0 +1,76 @@
+package bitflag
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// Perm is a Unix permission for one class of users: the file's owner, its
+// group, or everyone else. The flags are in the order of the data types
+// chapter's FlagRead, FlagWrite and FlagExecute, and of ls; they are not
+// the bits of an octal digit of a file mode, which Mode converts to.
+type Perm uint8
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Execute
+)
+
+// octal pairs each Perm with its bit in one octal digit of a file mode.
+var octal = []struct {
+	perm Perm
+	bit  fs.FileMode
+}{{Read, 4}, {Write, 2}, {Execute, 1}}
+
+func (p Perm) String() string {
+	switch p {
+	case Read:
+		return "read"
+	case Write:
+		return "write"
+	case Execute:
+		return "execute"
+	}
+	return fmt.Sprintf("Perm(%d)", uint8(p))
+}
+
+// Mode is the permission part of a file mode, as a set of Perms for each
+// class of users.
+type Mode struct {
+	Owner, Group, Other Set[Perm]
+}
+
+// ModeOf returns the permissions in m. Other bits of m, such as the
+// directory bit, are ignored.
+func ModeOf(m fs.FileMode) Mode {
+	digit := func(shift uint) Set[Perm] {
+		var s Set[Perm]
+		for _, o := range octal {
+			if m>>shift&o.bit != 0 {
+				s = s.Set(o.perm)
+			}
+		}
+		return s
+	}
+	return Mode{Owner: digit(6), Group: digit(3), Other: digit(0)}
+}
+
+// FileMode returns the permissions as an fs.FileMode, such as 0644.
+func (m Mode) FileMode() fs.FileMode {
+	digit := func(s Set[Perm]) fs.FileMode {
+		var d fs.FileMode
+		for _, o := range octal {
+			if s.Has(o.perm) {
+				d |= o.bit
+			}
+		}
+		return d
+	}
+	return digit(m.Owner)<<6 | digit(m.Group)<<3 | digit(m.Other)
+}
+
+// String returns the permissions as ls shows them, as in "rw-r--r--".
+func (m Mode) String() string {
+	return m.FileMode().String()[1:]
+}
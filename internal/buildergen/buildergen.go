@@ -0,0 +1,388 @@
+// Package buildergen writes fluent builders for struct types, so that the
+// structs chapter's hand-written PersonBuilder cannot drift from Person.
+// cmd/buildergen runs it from go generate.
+//
+// For a type Person it writes a PersonBuilder with a method per exported
+// field, and a Build method returning (Person, error). Struct tags on the
+// fields add checks, which the builder collects rather than stopping at
+// the first:
+//
+//	type Person struct {
+//		Name string `builder:"required,nonzero"`
+//		Age  int    `builder:"required"`
+//		City string
+//		Tags []string `builder:"-"`
+//	}
+//
+// required means Build fails unless the field's method was called, and
+// nonzero means the method records an error if given the zero value, such
+// as "" or 0. A field tagged "-" gets no method. If the type has a
+// Validate() error method, Build calls it too.
+package buildergen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// header starts every generated file, in the form that tools recognize.
+const header = "// Code generated by buildergen; DO NOT EDIT."
+
+// A field is one settable field of a struct.
+type field struct {
+	name     string
+	typ      string // as written in the source
+	expr     ast.Expr
+	required bool
+	nonzero  bool
+}
+
+// A structType is a struct that a builder is written for.
+type structType struct {
+	name   string
+	fields []field
+}
+
+// Generate reads the Go source file filename, or src if it is not nil,
+// and returns the source of a file in the same package holding a builder
+// for each of the named struct types.
+func Generate(filename string, src []byte, typeNames ...string) ([]byte, error) {
+	if len(typeNames) == 0 {
+		return nil, errors.New("buildergen: no types named")
+	}
+	fset := token.NewFileSet()
+	var srcArg any
+	if src != nil {
+		srcArg = src
+	}
+	file, err := parser.ParseFile(fset, filename, srcArg, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("buildergen: %w", err)
+	}
+
+	var types []structType
+	for _, name := range typeNames {
+		st, err := findStruct(fset, file, name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, st)
+	}
+
+	g := &generator{imports: map[string]bool{`"errors"`: true}}
+	for _, st := range types {
+		g.writeBuilder(st)
+	}
+	g.addSourceImports(file, types)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\n", header, file.Name.Name)
+	if paths := slices.Sorted(maps.Keys(g.imports)); len(paths) == 1 {
+		fmt.Fprintf(&out, "import %s\n", paths[0])
+	} else {
+		fmt.Fprintf(&out, "import (\n\t%s\n)\n", strings.Join(paths, "\n\t"))
+	}
+	out.Write(g.buf.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("buildergen: generated invalid code: %w\n%s", err, out.Bytes())
+	}
+	return formatted, nil
+}
+
+// findStruct finds the struct type name in file and reads its fields.
+func findStruct(fset *token.FileSet, file *ast.File, name string) (structType, error) {
+	var spec *ast.TypeSpec
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == name {
+			spec = ts
+		}
+		return spec == nil
+	})
+	if spec == nil {
+		return structType{}, fmt.Errorf("buildergen: no type %s in %s", name, fset.File(file.Pos()).Name())
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return structType{}, fmt.Errorf("buildergen: %s is not a struct type", name)
+	}
+	if spec.TypeParams != nil {
+		return structType{}, fmt.Errorf("buildergen: %s is generic, which is not supported", name)
+	}
+
+	out := structType{name: name}
+	for _, f := range st.Fields.List {
+		var opts []string
+		if f.Tag != nil {
+			tag, _ := strconv.Unquote(f.Tag.Value)
+			if t, ok := reflect.StructTag(tag).Lookup("builder"); ok {
+				opts = strings.Split(t, ",")
+			}
+		}
+		names := f.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(f.Type)}
+		}
+		for _, id := range names {
+			fd, skip, err := newField(fset, name, id.Name, f.Type, opts)
+			if err != nil {
+				return structType{}, err
+			}
+			if !skip {
+				out.fields = append(out.fields, fd)
+			}
+		}
+	}
+	return out, nil
+}
+
+// newField reads a field's tag options. skip is true for fields that get
+// no method.
+func newField(fset *token.FileSet, typeName, name string, expr ast.Expr, opts []string) (f field, skip bool, err error) {
+	var typ bytes.Buffer
+	if err := format.Node(&typ, fset, expr); err != nil {
+		return field{}, false, fmt.Errorf("buildergen: %s.%s: %w", typeName, name, err)
+	}
+	f = field{name: name, typ: typ.String(), expr: expr}
+	for _, opt := range opts {
+		switch strings.TrimSpace(opt) {
+		case "-":
+			return field{}, true, nil
+		case "required":
+			f.required = true
+		case "nonzero":
+			f.nonzero = true
+		case "":
+		default:
+			return field{}, false, fmt.Errorf("buildergen: %s.%s: unknown builder tag option %q", typeName, name, opt)
+		}
+	}
+	if !ast.IsExported(name) {
+		if len(opts) > 0 {
+			return field{}, false, fmt.Errorf("buildergen: %s.%s: unexported fields get no builder method, so cannot have builder tag options", typeName, name)
+		}
+		return field{}, true, nil
+	}
+	if name == "Build" {
+		return field{}, false, fmt.Errorf("buildergen: %s.Build: a field named Build would clash with the Build method; tag it builder:\"-\"", typeName)
+	}
+	return f, false, nil
+}
+
+// embeddedName returns the field name of an embedded field of type expr:
+// the name of its type without package or pointer.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	}
+	return ast.NewIdent("_")
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	imports map[string]bool // quoted paths, or "name path" for renamed imports
+}
+
+func (g *generator) printf(format string, args ...any) { fmt.Fprintf(&g.buf, format, args...) }
+
+func (g *generator) writeBuilder(st structType) {
+	b := st.name + "Builder"
+	var required []field
+	for _, f := range st.fields {
+		if f.required {
+			required = append(required, f)
+		}
+	}
+
+	g.printf("\n// %s builds a %s one field at a time. Its methods record any\n", b, st.name)
+	g.printf("// problem with the values they are given, and Build reports them all.\n")
+	g.printf("type %s struct {\n\tv %s\n", b, st.name)
+	if len(required) > 0 {
+		g.printf("\tset [%d]bool // which required fields have been set\n", len(required))
+	}
+	g.printf("\terrs []error\n}\n")
+
+	g.printf("\n// New%s returns a builder for a %s with every field unset.\n", b, st.name)
+	g.printf("func New%s() *%s { return &%s{} }\n", b, b, b)
+
+	for _, f := range st.fields {
+		g.printf("\n// %s sets %s.%s", f.name, st.name, f.name)
+		switch {
+		case f.required && f.nonzero:
+			g.printf(", which is required and must not be %s.\n", zeroWord(f.expr))
+		case f.required:
+			g.printf(", which is required.\n")
+		case f.nonzero:
+			g.printf(", which must not be %s.\n", zeroWord(f.expr))
+		default:
+			g.printf(".\n")
+		}
+		g.printf("func (b *%s) %s(v %s) *%s {\n", b, f.name, f.typ, b)
+		if f.nonzero {
+			g.printf("\tif %s {\n", g.isZero(f.expr))
+			g.printf("\t\tb.errs = append(b.errs, errors.New(%q))\n\t}\n",
+				st.name+"."+f.name+" must not be "+zeroWord(f.expr))
+		}
+		g.printf("\tb.v.%s = v\n", f.name)
+		if i := slices.IndexFunc(required, func(r field) bool { return r.name == f.name }); i >= 0 {
+			g.printf("\tb.set[%d] = true\n", i)
+		}
+		g.printf("\treturn b\n}\n")
+	}
+
+	g.printf("\n// Build returns the %s, or an error joining every problem found:\n", st.name)
+	g.printf("// those the setters recorded, required fields never set, and the\n")
+	g.printf("// error from %s's Validate method, if it has one.\n", st.name)
+	g.printf("func (b *%s) Build() (%s, error) {\n", b, st.name)
+	g.printf("\terrs := b.errs[:len(b.errs):len(b.errs)]\n")
+	for i, f := range required {
+		g.printf("\tif !b.set[%d] {\n\t\terrs = append(errs, errors.New(%q))\n\t}\n", i, st.name+"."+f.name+" is required")
+	}
+	g.printf("\tif v, ok := any(&b.v).(interface{ Validate() error }); ok {\n")
+	g.printf("\t\tif err := v.Validate(); err != nil {\n\t\t\terrs = append(errs, err)\n\t\t}\n\t}\n")
+	g.printf("\tif err := errors.Join(errs...); err != nil {\n\t\treturn %s{}, err\n\t}\n", st.name)
+	g.printf("\treturn b.v, nil\n}\n")
+}
+
+// isZero returns an expression reporting whether v is the zero value of
+// the type expr, written the way a person would for the common types.
+func (g *generator) isZero(expr ast.Expr) string {
+	switch kindOf(expr) {
+	case "string":
+		return `v == ""`
+	case "number":
+		return "v == 0"
+	case "bool":
+		return "!v"
+	case "len":
+		return "len(v) == 0"
+	case "nil":
+		return "v == nil"
+	}
+	g.imports[`"reflect"`] = true
+	return "reflect.ValueOf(v).IsZero()"
+}
+
+// zeroWord describes the zero value of the type expr in an error message.
+func zeroWord(expr ast.Expr) string {
+	switch kindOf(expr) {
+	case "string", "len":
+		return "empty"
+	case "bool":
+		return "false"
+	case "nil":
+		return "nil"
+	}
+	return "zero"
+}
+
+// kindOf sorts the type expr into the groups isZero knows, by its syntax
+// alone: a named type such as time.Duration is "other".
+func kindOf(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "bool"
+		case "any", "error":
+			return "nil"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune", "float32", "float64", "complex64", "complex128":
+			return "number"
+		}
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "len"
+		}
+	case *ast.MapType:
+		return "len"
+	case *ast.StarExpr, *ast.FuncType, *ast.ChanType, *ast.InterfaceType:
+		return "nil"
+	}
+	return "other"
+}
+
+// importName guesses the name of the package at path, as goimports does,
+// without loading it: the last element of the path, less a ".vN" suffix
+// or a "go-" prefix, or the element before it if the last is a major
+// version such as "v2". So "math/rand/v2" is rand and "gopkg.in/yaml.v3"
+// is yaml.
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// isMajorVersion reports whether s is a major version such as "v2".
+func isMajorVersion(s string) bool {
+	n, ok := strings.CutPrefix(s, "v")
+	return ok && n != "" && strings.Trim(n, "0123456789") == ""
+}
+
+// addSourceImports adds the imports of file that the field types use.
+func (g *generator) addSourceImports(file *ast.File, types []structType) {
+	used := map[string]bool{}
+	for _, st := range types {
+		for _, f := range st.fields {
+			ast.Inspect(f.expr, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if id, ok := sel.X.(*ast.Ident); ok {
+						used[id.Name] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := importName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !used[name] {
+			continue
+		}
+		if spec.Name != nil {
+			g.imports[spec.Name.Name+" "+spec.Path.Value] = true
+		} else {
+			g.imports[spec.Path.Value] = true
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package buildergen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+)
+
+const order = `package shop
+
+import (
+	"math/rand/v2"
+	"time"
+
+	str "strings"
+)
+
+type Order struct {
+	ID       int               ` + "`builder:\"required,nonzero\"`" + `
+	Customer string            ` + "`json:\"customer\" builder:\"nonzero\"`" + `
+	Items    []string          ` + "`builder:\"nonzero\"`" + `
+	Notes    *str.Builder      ` + "`builder:\"nonzero\"`" + `
+	Timeout  time.Duration     ` + "`builder:\"nonzero\"`" + `
+	Rand     *rand.Rand
+	Paid     bool
+	Internal map[string]string ` + "`builder:\"-\"`" + `
+	secret   string
+	Address
+}
+
+type Address struct{ Street, City string }
+
+func (o Order) Validate() error { return nil }
+`
+
+func TestGenerate(t *testing.T) {
+	out, err := Generate("order.go", []byte(order), "Order", "Address")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// Code generated by buildergen; DO NOT EDIT.\n",
+		"\t\"reflect\"\n",
+		"\tstr \"strings\"\n",
+		"\t\"time\"\n",
+		"\t\"math/rand/v2\"\n",
+		"func (b *OrderBuilder) Rand(v *rand.Rand) *OrderBuilder {",
+		"func (b *OrderBuilder) ID(v int) *OrderBuilder {\n\tif v == 0 {",
+		"func (b *OrderBuilder) Items(v []string) *OrderBuilder {\n\tif len(v) == 0 {",
+		"func (b *OrderBuilder) Notes(v *str.Builder) *OrderBuilder {\n\tif v == nil {",
+		"func (b *OrderBuilder) Timeout(v time.Duration) *OrderBuilder {\n\tif reflect.ValueOf(v).IsZero() {",
+		`errors.New("Order.Customer must not be empty")`,
+		`errors.New("Order.ID is required")`,
+		"func (b *OrderBuilder) Address(v Address) *OrderBuilder {",
+		"func (b *AddressBuilder) City(v string) *AddressBuilder {",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("generated code lacks %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Internal(", "secret(", "set [0]bool"} {
+		if strings.Contains(string(out), unwanted) {
+			t.Errorf("generated code has %q", unwanted)
+		}
+	}
+	typeCheck(t, order, string(out))
+}
+
+// typeCheck reports whether the source files compile together.
+func typeCheck(t *testing.T, srcs ...string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for i, src := range srcs {
+		f, err := parser.ParseFile(fset, string(rune('a'+i))+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "gc", nil)}
+	if _, err := conf.Check("shop", fset, files, nil); err != nil {
+		t.Errorf("generated code does not compile: %v\n%s", err, srcs[len(srcs)-1])
+	}
+}
+
+func TestImportName(t *testing.T) {
+	for path, want := range map[string]string{
+		"time":                        "time",
+		"math/rand/v2":                "rand",
+		"github.com/org/proj/v10":     "proj",
+		"gopkg.in/yaml.v3":            "yaml",
+		"github.com/mattn/go-sqlite3": "sqlite3",
+		"example.com/v2":              "example",
+		"v2":                          "v2",
+	} {
+		if got := importName(path); got != want {
+			t.Errorf("importName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		src, typ, want string
+	}{
+		{"package p\ntype T struct{ A int `builder:\"optional\"` }", "T", `unknown builder tag option "optional"`},
+		{"package p\ntype T int", "T", "T is not a struct type"},
+		{"package p\ntype T struct{}", "U", "no type U in t.go"},
+		{"package p\ntype T[E any] struct{ A E }", "T", "T is generic"},
+		{"package p\ntype T struct{ a int `builder:\"required\"` }", "T", "T.a: unexported fields"},
+		{"package p\ntype T struct{ Build bool }", "T", "clash with the Build method"},
+		{"package p\ntype T struct{", "T", "buildergen: t.go:2"},
+	}
+	for _, tt := range tests {
+		_, err := Generate("t.go", []byte(tt.src), tt.typ)
+		if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.HasPrefix(err.Error(), "buildergen: ") {
+			t.Errorf("Generate(%q) error = %v, want one containing %q", tt.src, err, tt.want)
+		}
+	}
+	if _, err := Generate("t.go", []byte("package p"), nil...); err == nil {
+		t.Error("Generate with no types did not fail")
+	}
+}
+
+// TestChapterBuilder checks that the structs chapter's generated
+// PersonBuilder is up to date with Person.
+func TestChapterBuilder(t *testing.T) {
+	const dir = "../../go_tutorial/ch07/"
+	want, err := os.ReadFile(dir + "person_builder.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Generate(dir+"7_structs_and_methods_examples.go", nil, "Person")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("person_builder.go is out of date; run go generate ./go_tutorial/ch07")
+	}
+}
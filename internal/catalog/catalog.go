@@ -0,0 +1,61 @@
+// Package catalog lists every chapter that ships runnable examples.
+package catalog
+
+import (
+	"strconv"
+
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch02"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch03"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch04"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch05"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch06"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch07"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch08"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch09"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch10"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch11"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch12"
+	"github.com/sumit-covlant/go_tutorial/go_tutorial/ch13"
+	"github.com/sumit-covlant/go_tutorial/internal/tutor"
+)
+
+var chapters = []tutor.Chapter{
+	ch02.Chapter,
+	ch03.Chapter,
+	ch04.Chapter,
+	ch05.Chapter,
+	ch06.Chapter,
+	ch07.Chapter,
+	ch08.Chapter,
+	ch09.Chapter,
+	ch10.Chapter,
+	ch11.Chapter,
+	ch12.Chapter,
+	ch13.Chapter,
+}
+
+// Chapters returns all chapters in tutorial order.
+func Chapters() []tutor.Chapter {
+	return chapters
+}
+
+// Lookup finds a chapter by number ("12") or slug ("concurrency").
+func Lookup(key string) (tutor.Chapter, bool) {
+	n, err := strconv.Atoi(key)
+	for _, c := range chapters {
+		if (err == nil && c.Number == n) || c.Slug == key {
+			return c, true
+		}
+	}
+	return tutor.Chapter{}, false
+}
+
+// LookupExercise finds an exercise by name and returns it with its chapter.
+func LookupExercise(name string) (tutor.Chapter, tutor.Exercise, bool) {
+	for _, c := range chapters {
+		if e, ok := c.Exercise(name); ok {
+			return c, e, true
+		}
+	}
+	return tutor.Chapter{}, tutor.Exercise{}, false
+}
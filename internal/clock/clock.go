@@ -0,0 +1,38 @@
+// Package clock lets code that sleeps, waits on timers or sets deadlines run
+// either on real time or on a Fake that tests advance by hand.
+//
+// The concurrency chapter's examples take a Clock instead of calling
+// time.Sleep, time.After, time.Tick and context.WithTimeout directly, so the
+// whole chapter can run in virtual time under test.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the subset of the time package, plus context deadlines, that
+// the examples use.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+	// Tick returns a channel that delivers the time every d. Like
+	// time.Tick it returns nil if d <= 0, and the ticker is never stopped.
+	Tick(d time.Duration) <-chan time.Time
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// Real returns the Clock backed by the time package.
+func Real() Clock { return realClock{} }
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Tick(d time.Duration) <-chan time.Time  { return time.Tick(d) }
+
+func (realClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
@@ -0,0 +1,217 @@
+package clock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Step is called.
+// Sleepers, After channels, tickers and timeouts fire in deadline order as
+// the clock passes them; timers due at the same instant fire in the order
+// they were set. The zero value is not usable; call NewFake.
+type Fake struct {
+	mu       sync.Mutex
+	cond     *sync.Cond // signalled when sleeping changes
+	now      time.Time
+	seq      int
+	timers   []*timer // sorted by when, then seq
+	sleeping int
+}
+
+type timer struct {
+	when   time.Time
+	seq    int
+	period time.Duration // non-zero for tickers
+	fire   func(now time.Time)
+}
+
+// NewFake returns a Fake clock set to start.
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep blocks until the clock has been advanced by d.
+func (f *Fake) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	ch := f.After(d)
+
+	f.mu.Lock()
+	f.sleeping++
+	f.cond.Broadcast()
+	f.mu.Unlock()
+
+	<-ch
+
+	f.mu.Lock()
+	f.sleeping--
+	f.cond.Broadcast()
+	f.mu.Unlock()
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	f.add(d, 0, func(now time.Time) { ch <- now })
+	return ch
+}
+
+func (f *Fake) Tick(d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	f.add(d, d, func(now time.Time) {
+		// Drop the tick if the reader is behind, as time.Ticker does.
+		select {
+		case ch <- now:
+		default:
+		}
+	})
+	return ch
+}
+
+// WithTimeout returns a context that is cancelled once the clock passes
+// now+d. Its Err is context.DeadlineExceeded from then on.
+func (f *Fake) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	t := f.add(d, 0, func(time.Time) { cancel(context.DeadlineExceeded) })
+	dctx := &deadlineCtx{Context: ctx, deadline: t.when}
+	return dctx, func() {
+		f.remove(t)
+		cancel(context.Canceled)
+	}
+}
+
+// deadlineCtx reports the fake deadline, and DeadlineExceeded rather than
+// Canceled once the timer has fired.
+type deadlineCtx struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c *deadlineCtx) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c *deadlineCtx) Err() error {
+	err := c.Context.Err()
+	if err != nil && context.Cause(c.Context) == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// Advance moves the clock forward by d, firing every timer that falls due
+// on the way.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	end := f.now.Add(d)
+	f.mu.Unlock()
+
+	for {
+		f.mu.Lock()
+		if len(f.timers) == 0 || f.timers[0].when.After(end) {
+			f.now = end
+			f.mu.Unlock()
+			return
+		}
+		fire, now := f.pop()
+		f.mu.Unlock()
+		fire(now)
+	}
+}
+
+// Step fires the earliest pending timer, moving the clock to its deadline
+// if that is still ahead. It reports false if no timer is pending.
+//
+// Firing one timer at a time and letting the woken goroutine run before the
+// next Step makes the order of events reproducible even when several timers
+// share a deadline.
+func (f *Fake) Step() bool {
+	f.mu.Lock()
+	if len(f.timers) == 0 {
+		f.mu.Unlock()
+		return false
+	}
+	fire, now := f.pop()
+	f.mu.Unlock()
+	fire(now)
+	return true
+}
+
+// Sleeping returns the number of goroutines blocked in Sleep.
+func (f *Fake) Sleeping() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sleeping
+}
+
+// BlockUntil waits until n goroutines are blocked in Sleep. Tests call it
+// before Advance so they know the sleepers have started waiting.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for f.sleeping != n {
+		f.cond.Wait()
+	}
+}
+
+// add schedules fire to run d from now, and every period after that if
+// period is non-zero. A timer that is already due fires immediately.
+func (f *Fake) add(d, period time.Duration, fire func(time.Time)) *timer {
+	f.mu.Lock()
+	f.seq++
+	t := &timer{when: f.now.Add(d), seq: f.seq, period: period, fire: fire}
+	if d <= 0 && period == 0 {
+		now := f.now
+		f.mu.Unlock()
+		fire(now)
+		return t
+	}
+	f.insert(t)
+	f.mu.Unlock()
+	return t
+}
+
+func (f *Fake) insert(t *timer) {
+	i, _ := slices.BinarySearchFunc(f.timers, t, func(a, b *timer) int {
+		if c := a.when.Compare(b.when); c != 0 {
+			return c
+		}
+		return a.seq - b.seq
+	})
+	f.timers = slices.Insert(f.timers, i, t)
+}
+
+func (f *Fake) remove(t *timer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i := slices.Index(f.timers, t); i >= 0 {
+		f.timers = slices.Delete(f.timers, i, i+1)
+	}
+}
+
+// pop removes the earliest timer, moves the clock up to it and reschedules
+// it if it is a ticker. The caller holds f.mu and must call the returned
+// function after releasing it.
+func (f *Fake) pop() (func(time.Time), time.Time) {
+	t := f.timers[0]
+	f.timers = f.timers[1:]
+	if t.when.After(f.now) {
+		f.now = t.when
+	}
+	if t.period > 0 {
+		f.seq++
+		f.insert(&timer{when: t.when.Add(t.period), seq: f.seq, period: t.period, fire: t.fire})
+	}
+	return t.fire, f.now
+}
@@ -0,0 +1,118 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeSleep(t *testing.T) {
+	f := NewFake(epoch)
+	done := make(chan time.Time)
+	go func() {
+		f.Sleep(time.Second)
+		done <- f.Now()
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(999 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before its deadline")
+	default:
+	}
+
+	f.Advance(time.Millisecond)
+	if got := <-done; !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("woke at %v, want %v", got, epoch.Add(time.Second))
+	}
+	if n := f.Sleeping(); n != 0 {
+		t.Errorf("Sleeping() = %d after wake-up", n)
+	}
+}
+
+func TestFakeAfterOrder(t *testing.T) {
+	f := NewFake(epoch)
+	a := f.After(2 * time.Second)
+	b := f.After(time.Second)
+	c := f.After(time.Second)
+
+	var got []string
+	for f.Step() {
+		select {
+		case <-a:
+			got = append(got, "a")
+		case <-b:
+			got = append(got, "b")
+		case <-c:
+			got = append(got, "c")
+		}
+	}
+	if want := "[b c a]"; fmt.Sprint(got) != want {
+		t.Errorf("fired %v, want %s", got, want)
+	}
+	if !f.Now().Equal(epoch.Add(2 * time.Second)) {
+		t.Errorf("Now() = %v after the last timer", f.Now())
+	}
+}
+
+func TestFakeTick(t *testing.T) {
+	f := NewFake(epoch)
+	tick := f.Tick(200 * time.Millisecond)
+
+	for i := 1; i <= 3; i++ {
+		f.Advance(200 * time.Millisecond)
+		if got, want := <-tick, epoch.Add(time.Duration(i)*200*time.Millisecond); !got.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, got, want)
+		}
+	}
+
+	// A reader that falls behind misses ticks rather than queueing them.
+	f.Advance(time.Second)
+	<-tick
+	select {
+	case <-tick:
+		t.Error("missed ticks were queued")
+	default:
+	}
+
+	if f.Tick(0) != nil {
+		t.Error("Tick(0) returned a channel")
+	}
+}
+
+func TestFakeWithTimeout(t *testing.T) {
+	f := NewFake(epoch)
+	ctx, cancel := f.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(epoch.Add(time.Second)) {
+		t.Errorf("Deadline() = %v, %v", d, ok)
+	}
+	f.Advance(500 * time.Millisecond)
+	if ctx.Err() != nil {
+		t.Fatalf("context done early: %v", ctx.Err())
+	}
+	f.Advance(500 * time.Millisecond)
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestFakeWithTimeoutCancel(t *testing.T) {
+	f := NewFake(epoch)
+	ctx, cancel := f.WithTimeout(context.Background(), time.Second)
+	cancel()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want Canceled", ctx.Err())
+	}
+	if f.Step() {
+		t.Error("cancel left the timeout pending")
+	}
+}
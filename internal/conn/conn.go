@@ -0,0 +1,235 @@
+// Package conn builds connection settings with functional options:
+//
+//	cfg, err := conn.New("db.example.com", conn.WithTLS(), conn.WithRetries(5))
+//
+// It replaces the functions chapter's connect(host, options ...string),
+// which had to guess what each string meant, with one typed option per
+// setting. New reports options that contradict each other, such as
+// WithTLS and WithPlaintext together, instead of letting the last one win.
+//
+// Settings not given as options can come from environment variables, by
+// passing WithEnv; explicit options take precedence over the environment,
+// which takes precedence over the defaults.
+package conn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings for connecting to a server.
+type Config struct {
+	Host    string
+	Port    int
+	TLS     bool
+	Timeout time.Duration // for each attempt
+	Retries int           // attempts after the first
+}
+
+// Defaults for settings that are not given.
+const (
+	DefaultPort    = 8080
+	DefaultTLSPort = 443
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+)
+
+// Option sets one connection setting.
+type Option func(*builder)
+
+type builder struct {
+	cfg       Config
+	set       map[string]string // setting -> the option that set it
+	errs      []error
+	envPrefix string
+	useEnv    bool
+}
+
+// setting records that the option described by desc sets name, and reports
+// whether it should be applied. Setting the same thing twice the same way
+// is harmless; two different values are a conflict.
+func (b *builder) setting(name, desc string) bool {
+	if prev, ok := b.set[name]; ok {
+		if prev != desc {
+			b.errs = append(b.errs, fmt.Errorf("conn: %s conflicts with %s", desc, prev))
+		}
+		return false
+	}
+	b.set[name] = desc
+	return true
+}
+
+// WithTLS connects over TLS, on port 443 unless WithPort says otherwise.
+func WithTLS() Option {
+	return func(b *builder) {
+		if b.setting("tls", "WithTLS()") {
+			b.cfg.TLS = true
+		}
+	}
+}
+
+// WithPlaintext connects without TLS. It is the default, but saying so
+// makes New reject a WithTLS given elsewhere.
+func WithPlaintext() Option {
+	return func(b *builder) {
+		if b.setting("tls", "WithPlaintext()") {
+			b.cfg.TLS = false
+		}
+	}
+}
+
+// WithPort sets the port, from 1 to 65535.
+func WithPort(port int) Option {
+	return func(b *builder) { b.port(port, fmt.Sprintf("WithPort(%d)", port)) }
+}
+
+// WithTimeout sets how long each attempt may take. It must be positive.
+func WithTimeout(d time.Duration) Option {
+	return func(b *builder) { b.timeout(d, fmt.Sprintf("WithTimeout(%v)", d)) }
+}
+
+// WithRetries sets how many times to try again after a failed attempt.
+// Zero means a single attempt; a negative count is an error.
+func WithRetries(n int) Option {
+	return func(b *builder) { b.retries(n, fmt.Sprintf("WithRetries(%d)", n)) }
+}
+
+// WithEnv reads the settings that no other option gives from environment
+// variables named prefix followed by TLS, PORT, TIMEOUT and RETRIES, such
+// as DB_PORT for the prefix "DB_". TLS takes true or false, TIMEOUT a
+// duration such as "10s", and the others whole numbers. Unset or empty
+// variables leave the default alone.
+func WithEnv(prefix string) Option {
+	return func(b *builder) {
+		b.envPrefix, b.useEnv = prefix, true
+	}
+}
+
+func (b *builder) port(port int, desc string) {
+	if port < 1 || port > 65535 {
+		b.errs = append(b.errs, fmt.Errorf("conn: %s: port must be from 1 to 65535", desc))
+	} else if b.setting("port", desc) {
+		b.cfg.Port = port
+	}
+}
+
+func (b *builder) timeout(d time.Duration, desc string) {
+	if d <= 0 {
+		b.errs = append(b.errs, fmt.Errorf("conn: %s: timeout must be positive", desc))
+	} else if b.setting("timeout", desc) {
+		b.cfg.Timeout = d
+	}
+}
+
+func (b *builder) retries(n int, desc string) {
+	if n < 0 {
+		b.errs = append(b.errs, fmt.Errorf("conn: %s: retries cannot be negative", desc))
+	} else if b.setting("retries", desc) {
+		b.cfg.Retries = n
+	}
+}
+
+// env applies the environment variables for settings no option gave.
+func (b *builder) env() {
+	for _, name := range []string{"tls", "port", "timeout", "retries"} {
+		if _, ok := b.set[name]; ok {
+			continue
+		}
+		key := b.envPrefix + strings.ToUpper(name)
+		val := os.Getenv(key)
+		if val == "" {
+			continue
+		}
+		desc := "$" + key + "=" + val
+		bad := func(want string) {
+			b.errs = append(b.errs, fmt.Errorf("conn: %s: not %s", desc, want))
+		}
+		switch name {
+		case "tls":
+			if v, err := strconv.ParseBool(val); err != nil {
+				bad("true or false")
+			} else if b.setting(name, desc) {
+				b.cfg.TLS = v
+			}
+		case "port":
+			if v, err := strconv.Atoi(val); err != nil {
+				bad("a number")
+			} else {
+				b.port(v, desc)
+			}
+		case "timeout":
+			if v, err := time.ParseDuration(val); err != nil {
+				bad("a duration")
+			} else {
+				b.timeout(v, desc)
+			}
+		case "retries":
+			if v, err := strconv.Atoi(val); err != nil {
+				bad("a number")
+			} else {
+				b.retries(v, desc)
+			}
+		}
+	}
+}
+
+// New returns the settings for connecting to host with the given options.
+// host is a name or an IP address, without a port. Settings no option
+// gives take their defaults. The error lists every invalid or conflicting
+// option.
+func New(host string, opts ...Option) (Config, error) {
+	b := &builder{
+		cfg: Config{Host: strings.ToLower(host), Timeout: DefaultTimeout, Retries: DefaultRetries},
+		set: make(map[string]string),
+	}
+	switch {
+	case host == "":
+		b.errs = append(b.errs, errors.New("conn: no host"))
+	case strings.ContainsAny(host, "/@?# ") || (strings.Contains(host, ":") && net.ParseIP(host) == nil):
+		b.errs = append(b.errs, fmt.Errorf("conn: bad host %q; give the port with WithPort", host))
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.useEnv {
+		b.env()
+	}
+	if b.cfg.Port == 0 {
+		b.cfg.Port = DefaultPort
+		if b.cfg.TLS {
+			b.cfg.Port = DefaultTLSPort
+		}
+	}
+	if len(b.errs) > 0 {
+		return Config{}, errors.Join(b.errs...)
+	}
+	return b.cfg, nil
+}
+
+// URL returns the canonical URL for the settings, such as
+// "https://db.example.com:443?retries=3&timeout=30s". The port is always
+// given, and the query parameters are in order, so equal settings give
+// equal URLs.
+func (c Config) URL() *url.URL {
+	scheme := "http"
+	if c.TLS {
+		scheme = "https"
+	}
+	q := url.Values{}
+	q.Set("retries", strconv.Itoa(c.Retries))
+	q.Set("timeout", c.Timeout.String())
+	return &url.URL{
+		Scheme:   scheme,
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		RawQuery: q.Encode(),
+	}
+}
+
+// String returns the canonical URL as a string.
+func (c Config) String() string { return c.URL().String() }
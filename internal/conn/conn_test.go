@@ -0,0 +1,100 @@
+package conn
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		host string
+		opts []Option
+		want string
+	}{
+		{"localhost", nil, "http://localhost:8080?retries=3&timeout=30s"},
+		{"localhost", []Option{WithTLS()}, "https://localhost:443?retries=3&timeout=30s"},
+		{"DB.Example.com", []Option{WithTLS(), WithPort(8443), WithTimeout(5 * time.Second), WithRetries(0)},
+			"https://db.example.com:8443?retries=0&timeout=5s"},
+		{"::1", []Option{WithPlaintext(), WithPort(80)}, "http://[::1]:80?retries=3&timeout=30s"},
+		// Repeating an option with the same value is not a conflict.
+		{"localhost", []Option{WithPort(3000), WithTLS(), WithPort(3000)}, "https://localhost:3000?retries=3&timeout=30s"},
+	}
+	for _, tt := range tests {
+		cfg, err := New(tt.host, tt.opts...)
+		if err != nil {
+			t.Errorf("New(%q): %v", tt.host, err)
+			continue
+		}
+		if got := cfg.String(); got != tt.want {
+			t.Errorf("New(%q) URL = %s, want %s", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		host string
+		opts []Option
+		want []string
+	}{
+		{"localhost", []Option{WithTLS(), WithPlaintext()}, []string{"WithPlaintext() conflicts with WithTLS()"}},
+		{"localhost", []Option{WithPort(443), WithPort(8443)}, []string{"WithPort(8443) conflicts with WithPort(443)"}},
+		{"localhost", []Option{WithPort(0), WithTimeout(-time.Second), WithRetries(-1)},
+			[]string{"WithPort(0): port must be", "WithTimeout(-1s): timeout must be positive", "WithRetries(-1): retries cannot be negative"}},
+		{"", nil, []string{"no host"}},
+		{"localhost:8080", nil, []string{`bad host "localhost:8080"`}},
+		{"https://example.com", nil, []string{"bad host"}},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.host, tt.opts...)
+		if err == nil {
+			t.Errorf("New(%q) with %d options succeeded", tt.host, len(tt.opts))
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("New(%q) error %q does not mention %q", tt.host, err, want)
+			}
+		}
+	}
+}
+
+func TestWithEnv(t *testing.T) {
+	t.Setenv("DB_TLS", "true")
+	t.Setenv("DB_PORT", "6543")
+	t.Setenv("DB_TIMEOUT", "")
+	t.Setenv("DB_RETRIES", "7")
+
+	cfg, err := New("db", WithEnv("DB_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cfg.String(), "https://db:6543?retries=7&timeout=30s"; got != want {
+		t.Errorf("from env: %s, want %s", got, want)
+	}
+
+	// Options win over the environment, without a conflict.
+	cfg, err = New("db", WithPlaintext(), WithEnv("DB_"), WithRetries(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cfg.String(), "http://db:6543?retries=1&timeout=30s"; got != want {
+		t.Errorf("options over env: %s, want %s", got, want)
+	}
+
+	// Without WithEnv the environment is ignored.
+	if cfg, _ := New("db"); cfg.Port != DefaultPort {
+		t.Errorf("port %d without WithEnv", cfg.Port)
+	}
+
+	t.Setenv("DB_TLS", "yes please")
+	t.Setenv("DB_PORT", "99999")
+	t.Setenv("DB_TIMEOUT", "soon")
+	_, err = New("db", WithEnv("DB_"))
+	for _, want := range []string{"$DB_TLS=yes please: not true or false", "$DB_PORT=99999: port must be", "$DB_TIMEOUT=soon: not a duration"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error %v does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package exercise grades the exercises in the chapter packages.
+//
+// Each chapter's exercises.go holds stubs that panic with "TODO: ...", and
+// its exercises_test.go holds the graders, which only build with the
+// "grade" tag so that the stubs do not fail go test ./... . Reference
+// solutions replace the stubs under the "solution" tag. A Checker runs
+// one grader with go test -json and sorts what it printed into a Result.
+package exercise
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/tutor"
+)
+
+// Result is the outcome of grading an exercise.
+type Result struct {
+	Passed  bool
+	Stub    string   // the stub's panic message, if it is still in place
+	Build   []string // compiler errors
+	Errors  []string // what the grader reported
+	Panic   string   // a panic other than the stub's
+	Elapsed time.Duration
+}
+
+// Checker runs graders.
+type Checker struct {
+	Root string   // the module root
+	Go   string   // go command; "" means "go"
+	Tags []string // build tags to add to "grade"
+}
+
+// Dir returns the directory, relative to the module root, of the package
+// that holds a chapter's exercises.
+func Dir(c tutor.Chapter) string {
+	return filepath.Join("go_tutorial", fmt.Sprintf("ch%02d", c.Number))
+}
+
+// testEvent is one line of go test -json output; see go doc test2json.
+type testEvent struct {
+	Action     string
+	Test       string
+	Elapsed    float64
+	Output     string
+	OutputType string
+}
+
+// Check grades ex. The error is for failing to run the grader at all; an
+// exercise that does not pass is reported in the Result.
+func (c *Checker) Check(ctx context.Context, ch tutor.Chapter, ex tutor.Exercise) (*Result, error) {
+	goCmd := c.Go
+	if goCmd == "" {
+		goCmd = "go"
+	}
+	tags := strings.Join(append([]string{"grade"}, c.Tags...), ",")
+	cmd := exec.CommandContext(ctx, goCmd, "test", "-json", "-tags", tags,
+		"-run", "^"+ex.Test+"$", "./"+filepath.ToSlash(Dir(ch)))
+	cmd.Dir = c.Root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+
+	r := new(Result)
+	ran := false
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		var e testEvent
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("reading go test output: %v", err)
+		}
+		line := strings.TrimRight(e.Output, "\n")
+		switch {
+		case e.Action == "build-output" && !strings.HasPrefix(line, "#"):
+			r.Build = append(r.Build, line)
+		case e.Action == "output" && (e.OutputType == "error" || e.OutputType == "error-continue"):
+			r.Errors = append(r.Errors, strings.TrimSpace(line))
+		case e.Action == "output" && strings.HasPrefix(line, "panic: "):
+			msg := strings.TrimSuffix(strings.TrimPrefix(line, "panic: "), " [recovered, repanicked]")
+			if strings.HasPrefix(msg, "TODO: ") {
+				r.Stub = msg
+			} else if r.Panic == "" {
+				r.Panic = msg
+			}
+		case (e.Action == "pass" || e.Action == "fail") && e.Test == ex.Test:
+			ran = true
+			r.Passed = e.Action == "pass"
+			r.Elapsed = time.Duration(e.Elapsed * float64(time.Second))
+		}
+	}
+	if !ran && len(r.Build) == 0 && r.Stub == "" && r.Panic == "" {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = "it did not run"
+		}
+		return nil, fmt.Errorf("grading %s with %s: %s", ex.Name, ex.Test, msg)
+	}
+	return r, nil
+}
@@ -0,0 +1,142 @@
+package exercise
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+)
+
+func checker(t *testing.T, tags ...string) *Checker {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs go test on every chapter")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("no go command")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Checker{Root: root, Tags: tags}
+}
+
+// TestSolutionsPass checks every grader against the reference solution,
+// which shows the grader can be satisfied.
+func TestSolutionsPass(t *testing.T) {
+	c := checker(t, "solution")
+	for _, ch := range catalog.Chapters() {
+		for _, ex := range ch.Exercises {
+			r, err := c.Check(context.Background(), ch, ex)
+			if err != nil {
+				t.Errorf("%s: %v", ex.Name, err)
+				continue
+			}
+			if !r.Passed {
+				t.Errorf("%s: the solution fails its grader: %+v", ex.Name, r)
+			}
+		}
+	}
+}
+
+// TestStubsFail checks that every exercise starts out as a stub, and that
+// the grader reports it as one.
+func TestStubsFail(t *testing.T) {
+	c := checker(t)
+	names := make(map[string]bool)
+	for _, ch := range catalog.Chapters() {
+		if len(ch.Exercises) == 0 {
+			t.Errorf("chapter %d has no exercises", ch.Number)
+		}
+		for _, ex := range ch.Exercises {
+			if names[ex.Name] {
+				t.Errorf("two exercises are named %s", ex.Name)
+			}
+			names[ex.Name] = true
+			if len(ex.Hints) == 0 {
+				t.Errorf("%s has no hints", ex.Name)
+			}
+
+			r, err := c.Check(context.Background(), ch, ex)
+			if err != nil {
+				t.Errorf("%s: %v", ex.Name, err)
+				continue
+			}
+			if r.Passed || !strings.HasPrefix(r.Stub, "TODO: implement ") {
+				t.Errorf("%s: the stub was not reported as one: %+v", ex.Name, r)
+			}
+		}
+	}
+}
+
+func TestFailuresAreReported(t *testing.T) {
+	c := checker(t)
+	ch, ex, ok := catalog.LookupExercise("set-union")
+	if !ok {
+		t.Fatal("no set-union exercise")
+	}
+
+	// Work on a copy of the module so the exercise can be answered badly.
+	src := c.Root
+	c.Root = t.TempDir()
+	for _, name := range []string{"go.mod", "go.sum", "internal", "go_tutorial"} {
+		if err := copyPath(filepath.Join(src, name), filepath.Join(c.Root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub := filepath.Join(c.Root, Dir(ch), "exercises.go")
+	write := func(body string) {
+		t.Helper()
+		code := "//go:build !solution\n\npackage ch08\n\nfunc (s Set) Union(other Set) Set {\n" + body + "\n}\n"
+		if err := os.WriteFile(stub, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("return s")
+	r, err := c.Check(context.Background(), ch, ex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Passed || r.Stub != "" || len(r.Errors) == 0 || !strings.Contains(r.Errors[0], "Union") {
+		t.Errorf("wrong answer: got %+v", r)
+	}
+
+	write("return undefinedThing")
+	r, err = c.Check(context.Background(), ch, ex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Passed || len(r.Build) == 0 || !strings.Contains(r.Build[0], "undefinedThing") {
+		t.Errorf("compile error: got %+v", r)
+	}
+
+	write("var m map[int]int; m[0] = 1; return s")
+	r, err = c.Check(context.Background(), ch, ex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Passed || !strings.Contains(r.Panic, "nil map") {
+		t.Errorf("panic: got %+v", r)
+	}
+}
+
+func copyPath(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return os.CopyFS(dst, os.DirFS(src))
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
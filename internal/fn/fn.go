@@ -0,0 +1,54 @@
+// Package fn has generic helpers that take functions and return new ones:
+// composing them, fixing some of their arguments, caching their results,
+// and limiting how often they run.
+//
+// Each one is a closure of the kind the functions chapter builds by hand in
+// createCounter and createAdder, made generic. The benchmarks in the tests
+// show what the extra call through a closure costs.
+package fn
+
+import "sync"
+
+// Compose returns the function that applies f and then g, so that
+// Compose(g, f)(x) is g(f(x)), as in mathematics.
+func Compose[A, B, C any](g func(B) C, f func(A) B) func(A) C {
+	return func(a A) C { return g(f(a)) }
+}
+
+// Pipe returns the function that applies fs from left to right, each to
+// the result of the one before. Pipe() returns its argument unchanged.
+func Pipe[T any](fs ...func(T) T) func(T) T {
+	return func(v T) T {
+		for _, f := range fs {
+			v = f(v)
+		}
+		return v
+	}
+}
+
+// Curry turns a function of two arguments into a function of the first
+// that returns a function of the second, so that Curry(f)(a)(b) is f(a, b).
+func Curry[A, B, C any](f func(A, B) C) func(A) func(B) C {
+	return func(a A) func(B) C {
+		return func(b B) C { return f(a, b) }
+	}
+}
+
+// Uncurry undoes Curry.
+func Uncurry[A, B, C any](f func(A) func(B) C) func(A, B) C {
+	return func(a A, b B) C { return f(a)(b) }
+}
+
+// Partial fixes the first argument of f, returning a function of the
+// second.
+func Partial[A, B, C any](f func(A, B) C, a A) func(B) C {
+	return func(b B) C { return f(a, b) }
+}
+
+// Once returns a function that calls f the first time it is called and
+// returns that result ever after, even when called from several goroutines
+// at once. It is sync.OnceValue under another name, kept here next to its
+// relatives.
+func Once[T any](f func() T) func() T {
+	return sync.OnceValue(f)
+}
@@ -0,0 +1,286 @@
+package fn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/clock"
+)
+
+func add(a, b int) int { return a + b }
+func double(n int) int { return n * 2 }
+func inc(n int) int    { return n + 1 }
+
+func TestCompose(t *testing.T) {
+	if got := Compose(double, inc)(3); got != 8 {
+		t.Errorf("Compose(double, inc)(3) = %d, want 8", got)
+	}
+	label := Compose(strconv.Itoa, double)
+	if got := label(21); got != "42" {
+		t.Errorf("Compose(Itoa, double)(21) = %q", got)
+	}
+	if got := Pipe(inc, double, inc)(3); got != 9 {
+		t.Errorf("Pipe(inc, double, inc)(3) = %d, want 9", got)
+	}
+	if got := Pipe[string]()("same"); got != "same" {
+		t.Errorf("Pipe()(same) = %q", got)
+	}
+}
+
+func TestCurry(t *testing.T) {
+	add10 := Curry(add)(10)
+	if got := add10(5); got != 15 {
+		t.Errorf("Curry(add)(10)(5) = %d", got)
+	}
+	if got := Uncurry(Curry(add))(2, 3); got != 5 {
+		t.Errorf("Uncurry(Curry(add))(2, 3) = %d", got)
+	}
+	hasGo := Partial(strings.Contains, "golang")
+	if !hasGo("go") || hasGo("rust") {
+		t.Error("Partial(strings.Contains, golang) is wrong")
+	}
+}
+
+func TestOnce(t *testing.T) {
+	var calls atomic.Int32
+	get := Once(func() int { return int(calls.Add(1)) })
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if got := get(); got != 1 {
+				t.Errorf("Once gave %d", got)
+			}
+		})
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("f called %d times", calls.Load())
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	var calls []int
+	square := Memoize(func(n int) int {
+		calls = append(calls, n)
+		return n * n
+	})
+	for _, n := range []int{3, 4, 3, 0, 4, 0} {
+		if got := square(n); got != n*n {
+			t.Errorf("square(%d) = %d", n, got)
+		}
+	}
+	if fmt.Sprint(calls) != "[3 4 0]" {
+		t.Errorf("f called with %v, want [3 4 0]", calls)
+	}
+}
+
+func TestMemoizeLRU(t *testing.T) {
+	var calls []string
+	upper := MemoizeLRU(2, func(s string) string {
+		calls = append(calls, s)
+		return strings.ToUpper(s)
+	})
+	for _, s := range []string{"a", "b", "a", "c", "a", "b"} {
+		upper(s)
+	}
+	// "c" evicts "b", the least recently used; "b" is then computed again.
+	if got := strings.Join(calls, ""); got != "abcb" {
+		t.Errorf("f called with %q, want abcb", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MemoizeLRU(0, ...) did not panic")
+		}
+	}()
+	MemoizeLRU(0, strings.ToUpper)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := Memoize(func(n int) int {
+		calls.Add(1)
+		<-release
+		return n + 1
+	})
+
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	for range 8 {
+		started.Add(1)
+		wg.Go(func() {
+			started.Done()
+			if got := slow(1); got != 2 {
+				t.Errorf("slow(1) = %d", got)
+			}
+		})
+	}
+	started.Wait()
+	// Give every goroutine a moment to reach the cache before f returns.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("f called %d times for one argument", n)
+	}
+}
+
+func TestMemoizePanic(t *testing.T) {
+	fail := true
+	f := Memoize(func(n int) int {
+		if fail {
+			panic("boom")
+		}
+		return n
+	})
+	func() {
+		defer func() { recover() }()
+		f(1)
+	}()
+	fail = false
+	if got := f(1); got != 1 {
+		t.Errorf("after a panic f(1) = %d, want 1", got)
+	}
+}
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDebounce(t *testing.T) {
+	clk := clock.NewFake(epoch)
+	got := make(chan string, 10)
+	search := Debounce(clk, 100*time.Millisecond, func(q string) { got <- q })
+
+	search("g")
+	clk.BlockUntil(1)
+	clk.Advance(50 * time.Millisecond)
+	search("go")
+	clk.BlockUntil(2)
+	clk.Advance(50 * time.Millisecond) // "g" is due, but was overtaken
+	clk.Advance(50 * time.Millisecond)
+	if q := <-got; q != "go" {
+		t.Errorf("first call with %q, want go", q)
+	}
+
+	clk.BlockUntil(0) // the overtaken sleeper has woken too
+	search("golang")
+	clk.BlockUntil(1)
+	clk.Advance(100 * time.Millisecond)
+	if q := <-got; q != "golang" {
+		t.Errorf("second call with %q, want golang", q)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	clk := clock.NewFake(epoch)
+	var got []int
+	save := Throttle(clk, time.Second, func(n int) { got = append(got, n) })
+
+	var ran []bool
+	for n := range 6 {
+		ran = append(ran, save(n))
+		clk.Advance(400 * time.Millisecond)
+	}
+	// Calls at 0, 0.4, 0.8, 1.2, 1.6 and 2.0 seconds.
+	if fmt.Sprint(got) != "[0 3]" || fmt.Sprint(ran) != "[true false false true false false]" {
+		t.Errorf("ran %v, calls %v", ran, got)
+	}
+}
+
+// The benchmarks compare a direct call with the same work through the
+// closures above, to show what the indirection costs.
+
+var sink int
+
+func BenchmarkDirect(b *testing.B) {
+	for i := range b.N {
+		sink = double(inc(i))
+	}
+}
+
+func BenchmarkCompose(b *testing.B) {
+	f := Compose(double, inc)
+	for i := range b.N {
+		sink = f(i)
+	}
+}
+
+func BenchmarkPipe(b *testing.B) {
+	f := Pipe(inc, double)
+	for i := range b.N {
+		sink = f(i)
+	}
+}
+
+func BenchmarkCurry(b *testing.B) {
+	f := Curry(add)
+	for i := range b.N {
+		sink = f(i)(1)
+	}
+}
+
+func BenchmarkPartial(b *testing.B) {
+	f := Partial(add, 1)
+	for i := range b.N {
+		sink = f(i)
+	}
+}
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func BenchmarkFib20(b *testing.B) {
+	for range b.N {
+		sink = fib(20)
+	}
+}
+
+func BenchmarkMemoizeHit(b *testing.B) {
+	f := Memoize(fib)
+	f(20)
+	b.ResetTimer()
+	for range b.N {
+		sink = f(20)
+	}
+}
+
+func BenchmarkMemoizeLRU(b *testing.B) {
+	f := MemoizeLRU(64, double)
+	// Even calls cycle through 32 keys, which stay in the cache, and odd
+	// calls ask for a key never seen before, so half the calls miss.
+	for i := range b.N {
+		if i%2 == 0 {
+			sink = f(-1 - i/2%32)
+		} else {
+			sink = f(i)
+		}
+	}
+}
+
+func BenchmarkMemoizeParallel(b *testing.B) {
+	f := Memoize(double)
+	b.RunParallel(func(pb *testing.PB) {
+		i, sum := 0, 0
+		for pb.Next() {
+			sum += f(i % 64)
+			i++
+		}
+		_ = sum
+	})
+}
+
+func BenchmarkThrottle(b *testing.B) {
+	f := Throttle(clock.Real(), time.Hour, func(int) {})
+	for i := range b.N {
+		f(i)
+	}
+}
@@ -0,0 +1,89 @@
+package fn
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Memoize returns a function that gives the same results as f but calls f
+// only once for each argument, remembering every result. f should be a
+// pure function: one whose result depends only on its argument.
+//
+// The memoized function is safe for concurrent use. Callers that ask for
+// the same argument while f is working on it wait for that call rather
+// than making their own. If f panics, nothing is remembered and a waiting
+// caller calls f itself.
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	return newMemo(f, 0).get
+}
+
+// MemoizeLRU is like Memoize but remembers at most size results, forgetting
+// the least recently used when it needs room. It panics if size < 1.
+func MemoizeLRU[K comparable, V any](size int, f func(K) V) func(K) V {
+	if size < 1 {
+		panic("fn: MemoizeLRU size must be at least 1")
+	}
+	return newMemo(f, size).get
+}
+
+type memo[K comparable, V any] struct {
+	f     func(K) V
+	limit int // 0 for no limit
+
+	mu    sync.Mutex
+	calls map[K]*call[V] // finished and in-flight calls
+	order *list.List     // keys of finished calls, most recently used first; nil with no limit
+}
+
+type call[V any] struct {
+	done chan struct{} // closed when f returns or panics
+	v    V
+	ok   bool          // f returned
+	elem *list.Element // in memo.order once finished, with a limit
+}
+
+func newMemo[K comparable, V any](f func(K) V, limit int) *memo[K, V] {
+	m := &memo[K, V]{f: f, limit: limit, calls: make(map[K]*call[V])}
+	if limit > 0 {
+		m.order = list.New()
+	}
+	return m
+}
+
+func (m *memo[K, V]) get(k K) V {
+	m.mu.Lock()
+	if c, ok := m.calls[k]; ok {
+		if c.elem != nil {
+			m.order.MoveToFront(c.elem)
+		}
+		m.mu.Unlock()
+		<-c.done
+		if c.ok {
+			return c.v
+		}
+		return m.get(k)
+	}
+	c := &call[V]{done: make(chan struct{})}
+	m.calls[k] = c
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		switch {
+		case !c.ok:
+			delete(m.calls, k)
+		case m.order != nil:
+			c.elem = m.order.PushFront(k)
+			if m.order.Len() > m.limit {
+				oldest := m.order.Back()
+				m.order.Remove(oldest)
+				delete(m.calls, oldest.Value.(K))
+			}
+		}
+		m.mu.Unlock()
+		close(c.done)
+	}()
+	c.v = m.f(k)
+	c.ok = true
+	return c.v
+}
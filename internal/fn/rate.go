@@ -0,0 +1,60 @@
+package fn
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/clock"
+)
+
+// Debounce returns a function that delays calling f until wait has passed
+// without another call, as a search box waits for typing to pause. f is
+// then called once, with the argument of the last call, on a goroutine of
+// its own.
+//
+// Each call starts a goroutine that sleeps on clk for wait, so time can be
+// moved by hand in tests with a clock.Fake. Use clock.Real() otherwise.
+func Debounce[T any](clk clock.Clock, wait time.Duration, f func(T)) func(T) {
+	var (
+		mu   sync.Mutex
+		last int // number of the latest call
+	)
+	return func(v T) {
+		mu.Lock()
+		last++
+		n := last
+		mu.Unlock()
+
+		go func() {
+			clk.Sleep(wait)
+			mu.Lock()
+			latest := n == last
+			mu.Unlock()
+			if latest {
+				f(v)
+			}
+		}()
+	}
+}
+
+// Throttle returns a function that calls f at most once every interval,
+// dropping the calls in between. It reports whether f was called. The
+// first call always goes through.
+func Throttle[T any](clk clock.Clock, interval time.Duration, f func(T)) func(T) bool {
+	var (
+		mu   sync.Mutex
+		next time.Time // when the next call may go through
+	)
+	return func(v T) bool {
+		mu.Lock()
+		now := clk.Now()
+		if now.Before(next) {
+			mu.Unlock()
+			return false
+		}
+		next = now.Add(interval)
+		mu.Unlock()
+		f(v)
+		return true
+	}
+}
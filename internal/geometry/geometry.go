@@ -0,0 +1,100 @@
+// Package geometry is the one home of the Shape, Circle, Rectangle and
+// Triangle that the structs and interfaces chapters use, and of chapter 7's
+// Point.
+//
+// Shapes are placed in the plane rather than just sized, so besides Area
+// and Perimeter they have a bounding box, can say whether they contain a
+// point, and can be tested against each other with Intersects. Translate,
+// Scale and Rotate return a new shape and leave the old one alone; a
+// rotated Rectangle is no longer axis-aligned, so it comes back as a
+// Polygon.
+//
+// Shapes are written to JSON with a "type" field naming their kind, and
+// Unmarshal and Shapes read them back into the right Go type.
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// A Point is a position, or a displacement, in the plane.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Pt is shorthand for Point{x, y}.
+func Pt(x, y float64) Point { return Point{x, y} }
+
+// Add returns p moved by q.
+func (p Point) Add(q Point) Point { return Point{p.X + q.X, p.Y + q.Y} }
+
+// Sub returns the displacement from q to p.
+func (p Point) Sub(q Point) Point { return Point{p.X - q.X, p.Y - q.Y} }
+
+// Mul returns p scaled by f about the origin.
+func (p Point) Mul(f float64) Point { return Point{p.X * f, p.Y * f} }
+
+// Dist returns the distance between p and q.
+func (p Point) Dist(q Point) float64 { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+
+// Move moves p by dx and dy. Unlike chapter 7's Point.Move it has a
+// pointer receiver, so it changes p rather than a copy.
+func (p *Point) Move(dx, dy float64) {
+	p.X += dx
+	p.Y += dy
+}
+
+// Rotate returns p rotated by theta radians, anticlockwise, about c.
+func (p Point) Rotate(theta float64, c Point) Point {
+	sin, cos := math.Sincos(theta)
+	d := p.Sub(c)
+	return Point{c.X + d.X*cos - d.Y*sin, c.Y + d.X*sin + d.Y*cos}
+}
+
+// scale returns p scaled by f about c.
+func (p Point) scale(f float64, c Point) Point { return c.Add(p.Sub(c).Mul(f)) }
+
+// String formats p as "(3, 4)". It rounds to ten significant digits, so
+// that rounding errors from transforms do not show.
+func (p Point) String() string {
+	return "(" + strconv.FormatFloat(p.X, 'g', 10, 64) + ", " + strconv.FormatFloat(p.Y, 'g', 10, 64) + ")"
+}
+
+// cross returns the z component of the cross product of a and b, which is
+// positive if b is anticlockwise from a.
+func cross(a, b Point) float64 { return a.X*b.Y - a.Y*b.X }
+
+// Shape is a closed figure in the plane.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+	// Bounds returns the smallest axis-aligned rectangle holding the shape.
+	Bounds() Rectangle
+	// Contains reports whether p is inside the shape or on its edge.
+	Contains(p Point) bool
+
+	Translate(d Point) Shape
+	// Scale scales the shape by f about c. A negative f also turns it
+	// half a circle about c.
+	Scale(f float64, c Point) Shape
+	// Rotate rotates the shape by theta radians, anticlockwise, about c.
+	Rotate(theta float64, c Point) Shape
+}
+
+// Kind returns the name a shape is written to JSON with, such as "circle".
+func Kind(s Shape) string {
+	switch s.(type) {
+	case Circle:
+		return kindCircle
+	case Rectangle:
+		return kindRectangle
+	case Triangle:
+		return kindTriangle
+	case Polygon:
+		return kindPolygon
+	}
+	panic(fmt.Sprintf("geometry: unknown shape %T", s))
+}
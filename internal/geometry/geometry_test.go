@@ -0,0 +1,258 @@
+package geometry
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(a), math.Abs(b))
+}
+
+func randPoint(r *rand.Rand) Point { return Pt(r.Float64()*200-100, r.Float64()*200-100) }
+
+// randShape returns a random shape of any of the four kinds.
+func randShape(r *rand.Rand) Shape {
+	switch r.IntN(4) {
+	case 0:
+		return Circle{randPoint(r), r.Float64() * 50}
+	case 1:
+		a, b := randPoint(r), randPoint(r)
+		return Rect(a.X, a.Y, b.X, b.Y)
+	case 2:
+		return Triangle{randPoint(r), randPoint(r), randPoint(r)}
+	}
+	return RegularPolygon(3+r.IntN(10), Circle{randPoint(r), 1 + r.Float64()*50})
+}
+
+func TestFormulas(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		a, b := randPoint(r), randPoint(r)
+		rect := Rect(a.X, a.Y, b.X, b.Y)
+		w, h := math.Abs(a.X-b.X), math.Abs(a.Y-b.Y)
+		if !near(rect.Area(), w*h) || !near(rect.Perimeter(), 2*(w+h)) {
+			t.Fatalf("%v: area %g, perimeter %g", rect, rect.Area(), rect.Perimeter())
+		}
+		if p := rect.Polygon(); !near(p.Area(), rect.Area()) || !near(p.Perimeter(), rect.Perimeter()) {
+			t.Fatalf("%v as a polygon: area %g, perimeter %g", rect, p.Area(), p.Perimeter())
+		}
+
+		// Heron's formula, as chapter 10 uses, must agree with the cross
+		// product for any triangle built from valid sides.
+		sa, sb, sc := 1+r.Float64()*50, 1+r.Float64()*50, 1+r.Float64()*50
+		tri, err := TriangleFromSides(sa, sb, sc)
+		if sa+sb < sc || sb+sc < sa || sc+sa < sb {
+			if err == nil {
+				t.Fatalf("TriangleFromSides(%g, %g, %g) made %v", sa, sb, sc, tri)
+			}
+			continue
+		}
+		s := (sa + sb + sc) / 2
+		heron := math.Sqrt(s * (s - sa) * (s - sb) * (s - sc))
+		if err != nil || math.Abs(tri.Area()-heron) > 1e-6 || !near(tri.Perimeter(), sa+sb+sc) {
+			t.Fatalf("TriangleFromSides(%g, %g, %g) = %v, %v: area %g, want %g", sa, sb, sc, tri, err, tri.Area(), heron)
+		}
+		if !near(tri.B.Dist(tri.C), sa) || !near(tri.C.Dist(tri.A), sb) || !near(tri.A.Dist(tri.B), sc) {
+			t.Fatalf("TriangleFromSides(%g, %g, %g) = %v", sa, sb, sc, tri)
+		}
+	}
+
+	// A regular polygon with many sides is nearly its circle.
+	c := Circle{Pt(3, -2), 10}
+	p := RegularPolygon(10_000, c)
+	if math.Abs(p.Area()-c.Area()) > 1e-3 || math.Abs(p.Perimeter()-c.Perimeter()) > 1e-3 {
+		t.Errorf("10000-gon: area %g, perimeter %g; circle %g, %g", p.Area(), p.Perimeter(), c.Area(), c.Perimeter())
+	}
+	if _, err := TriangleFromSides(1, 0, 1); err == nil {
+		t.Error("TriangleFromSides accepted a zero side")
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 1000 {
+		s := randShape(r)
+		d, about := randPoint(r), randPoint(r)
+		f := r.Float64()*4 - 2
+		theta := r.Float64() * 2 * math.Pi
+
+		check := func(name string, got Shape, areaFactor, perimFactor float64) {
+			t.Helper()
+			if !near(got.Area(), s.Area()*areaFactor) || !near(got.Perimeter(), s.Perimeter()*perimFactor) {
+				t.Fatalf("%s of %v = %v: area %g, perimeter %g", name, s, got, got.Area(), got.Perimeter())
+			}
+		}
+		check("Translate", s.Translate(d), 1, 1)
+		check("Scale", s.Scale(f, about), f*f, math.Abs(f))
+		check("Rotate", s.Rotate(theta, about), 1, 1)
+
+		// A full turn, and a translation and its inverse, change nothing.
+		back := s.Rotate(theta, about).Rotate(2*math.Pi-theta, about).Translate(d).Translate(Pt(0, 0).Sub(d))
+		probe := s.Bounds().Center()
+		if back.Contains(probe) != s.Contains(probe) && !onEdge(s, probe) {
+			t.Fatalf("%v moved there and back contains %v differently", s, probe)
+		}
+	}
+
+	if _, ok := Rect(0, 0, 1, 1).Rotate(1, Pt(0, 0)).(Polygon); !ok {
+		t.Error("a rotated Rectangle is not a Polygon")
+	}
+	if got := Rect(0, 0, 2, 1).Scale(-1, Pt(0, 0)); got != Rect(-2, -1, 0, 0) {
+		t.Errorf("Scale(-1) = %v", got)
+	}
+}
+
+// onEdge reports whether p is so close to an edge of s that rounding can
+// put it either side.
+func onEdge(s Shape, p Point) bool {
+	if c, ok := s.(Circle); ok {
+		return math.Abs(c.Center.Dist(p)-c.Radius) < 1e-6
+	}
+	found := false
+	outline(s).edges(func(a, b Point) bool {
+		found = segmentDist(p, a, b) < 1e-6
+		return !found
+	})
+	return found
+}
+
+func TestContains(t *testing.T) {
+	// An L shape, which is not convex.
+	l, err := NewPolygon(Pt(0, 0), Pt(4, 0), Pt(4, 1), Pt(1, 1), Pt(1, 4), Pt(0, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		p    Point
+		want bool
+	}{
+		{Pt(0.5, 3), true},
+		{Pt(3, 0.5), true},
+		{Pt(2, 2), false}, // in the notch
+		{Pt(4, 0.5), true},
+		{Pt(1, 1), true},
+		{Pt(0, 4), true},
+		{Pt(-0.1, 2), false},
+		{Pt(2, 1.0001), false},
+	} {
+		if got := l.Contains(tt.p); got != tt.want {
+			t.Errorf("L.Contains(%v) = %t", tt.p, got)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 2000 {
+		s, p := randShape(r), randPoint(r)
+		if s.Contains(p) && !s.Bounds().Contains(p) {
+			t.Fatalf("%v contains %v but its bounds %v do not", s, p, s.Bounds())
+		}
+	}
+	if _, err := NewPolygon(Pt(0, 0), Pt(1, 1)); err == nil {
+		t.Error("NewPolygon accepted two vertices")
+	}
+}
+
+func TestIntersects(t *testing.T) {
+	unit := Rect(0, 0, 1, 1)
+	tests := []struct {
+		a, b Shape
+		want bool
+	}{
+		{unit, Rect(1, 1, 2, 2), true}, // corners touch
+		{unit, Rect(1.01, 0, 2, 1), false},
+		{unit, Rect(-1, -1, 3, 3), true}, // inside
+		{Circle{Pt(0, 0), 1}, Circle{Pt(2, 0), 1}, true},
+		{Circle{Pt(0, 0), 1}, Circle{Pt(2.01, 0), 1}, false},
+		{Circle{Pt(3, 0.5), 2}, unit, true},
+		{Circle{Pt(2, 2), 1.4}, unit, false}, // near the corner, but not at it
+		{Circle{Pt(2, 2), 1.42}, unit, true},
+		{Circle{Pt(0.5, 0.5), 0.1}, unit, true},
+		{Triangle{Pt(0, 2.1), Pt(2.1, 0), Pt(2, 2)}, unit, false},
+		{Triangle{Pt(0, 2), Pt(2, 0), Pt(2, 2)}, unit, true}, // touches (1, 1)
+		{Triangle{Pt(0, 1.9), Pt(1.9, 0), Pt(2, 2)}, unit, true},
+		// A cross: neither holds a vertex of the other, but edges cross.
+		{Rect(-1, 0, 2, 1), Rect(0, -1, 1, 2), true},
+	}
+	for _, tt := range tests {
+		if got := Intersects(tt.a, tt.b); got != tt.want {
+			t.Errorf("Intersects(%v, %v) = %t", tt.a, tt.b, got)
+		}
+		if got := Intersects(tt.b, tt.a); got != tt.want {
+			t.Errorf("Intersects(%v, %v) = %t", tt.b, tt.a, got)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 2000 {
+		a, b := randShape(r), randShape(r)
+		if Intersects(a, b) != Intersects(b, a) {
+			t.Fatalf("Intersects(%v, %v) is not symmetric", a, b)
+		}
+		if Intersects(a, b) && !a.Bounds().Overlaps(b.Bounds()) {
+			t.Fatalf("%v and %v intersect but their bounds do not", a, b)
+		}
+		if p := randPoint(r); a.Contains(p) && b.Contains(p) && !Intersects(a, b) {
+			t.Fatalf("%v and %v share %v but do not intersect", a, b, p)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	shapes := Shapes{
+		Circle{Pt(1, 2), 3},
+		Rect(0, 0, 4, 6),
+		Triangle{Pt(0, 0), Pt(3, 0), Pt(0, 4)},
+		RegularPolygon(4, Circle{Radius: 1}),
+	}
+	data, err := json.Marshal(shapes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `[{"type":"circle","center":{"x":1,"y":2},"radius":3},{"type":"rectangle",`) {
+		t.Errorf("Marshal = %s", data)
+	}
+	var got Shapes
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, shapes) {
+		t.Errorf("round trip gave %v, want %v", got, shapes)
+	}
+
+	for _, in := range []string{
+		`[{"center":{"x":1,"y":2}}]`,
+		`[{"type":"hexagon"}]`,
+		`[{"type":"circle","radius":"big"}]`,
+		`[{"type":"circle","radius":-1}]`,
+		`[{"type":"rectangle","min":{"x":4,"y":0},"max":{"x":0,"y":6}}]`,
+		`[{"type":"rectangle","min":{"x":0,"y":6},"max":{"x":4,"y":0}}]`,
+		`[{"type":"polygon","vertices":[{"x":0,"y":0}]}]`,
+		`{"type":"circle"}`,
+	} {
+		if err := json.Unmarshal([]byte(in), &got); err == nil || !strings.Contains(err.Error(), "geometry: ") {
+			t.Errorf("Unmarshal(%s) error = %v", in, err)
+		}
+	}
+}
+
+func TestPoint(t *testing.T) {
+	p := Pt(3, 4)
+	p.Move(1, 1)
+	if p != Pt(4, 5) {
+		t.Errorf("after Move, p = %v", p)
+	}
+	if d := Pt(0, 0).Dist(Pt(3, 4)); d != 5 {
+		t.Errorf("Dist = %g", d)
+	}
+	if q := Pt(1, 0).Rotate(math.Pi/2, Pt(0, 0)); !near(q.X+1, 1) || !near(q.Y, 1) {
+		t.Errorf("Rotate = %v", q)
+	}
+	if s := Pt(1.5, -2).String(); s != "(1.5, -2)" {
+		t.Errorf("String() = %q", s)
+	}
+}
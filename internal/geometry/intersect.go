@@ -0,0 +1,101 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Intersects reports whether a and b have at least one point in common,
+// counting their insides as well as their edges: a shape inside another
+// intersects it.
+//
+// It knows the shapes of this package; any other Shape makes it panic.
+func Intersects(a, b Shape) bool {
+	if !a.Bounds().Overlaps(b.Bounds()) {
+		return false
+	}
+	ca, aIsCircle := a.(Circle)
+	cb, bIsCircle := b.(Circle)
+	switch {
+	case aIsCircle && bIsCircle:
+		return ca.Center.Dist(cb.Center) <= ca.Radius+cb.Radius
+	case aIsCircle:
+		return circleMeetsPolygon(ca, outline(b))
+	case bIsCircle:
+		return circleMeetsPolygon(cb, outline(a))
+	}
+	pa, pb := outline(a), outline(b)
+	if len(pa.Vertices) == 0 || len(pb.Vertices) == 0 {
+		return false
+	}
+	// Either an edge of one crosses an edge of the other, or one lies
+	// wholly inside the other and so holds all of its vertices.
+	crossed := false
+	pa.edges(func(a1, a2 Point) bool {
+		pb.edges(func(b1, b2 Point) bool {
+			crossed = segmentsMeet(a1, a2, b1, b2)
+			return !crossed
+		})
+		return !crossed
+	})
+	return crossed || pa.Contains(pb.Vertices[0]) || pb.Contains(pa.Vertices[0])
+}
+
+// outline returns s as a Polygon.
+func outline(s Shape) Polygon {
+	switch s := s.(type) {
+	case Polygon:
+		return s
+	case Rectangle:
+		return s.Polygon()
+	case Triangle:
+		return s.Polygon()
+	}
+	panic(fmt.Sprintf("geometry: cannot test %T for intersection", s))
+}
+
+func circleMeetsPolygon(c Circle, p Polygon) bool {
+	if p.Contains(c.Center) {
+		return true
+	}
+	near := false
+	p.edges(func(a, b Point) bool {
+		near = segmentDist(c.Center, a, b) <= c.Radius
+		return !near
+	})
+	return near
+}
+
+// segmentDist returns the distance from p to the nearest point of the
+// segment from a to b.
+func segmentDist(p, a, b Point) float64 {
+	ab, ap := b.Sub(a), p.Sub(a)
+	l2 := ab.X*ab.X + ab.Y*ab.Y
+	if l2 == 0 {
+		return p.Dist(a)
+	}
+	t := math.Max(0, math.Min(1, (ap.X*ab.X+ap.Y*ab.Y)/l2))
+	return p.Dist(a.Add(ab.Mul(t)))
+}
+
+// onSegment reports whether p lies on the segment from a to b, allowing
+// for rounding in the coordinates.
+func onSegment(p, a, b Point) bool {
+	scale := 1 + max(math.Abs(a.X), math.Abs(a.Y), math.Abs(b.X), math.Abs(b.Y))
+	return segmentDist(p, a, b) <= 1e-9*scale
+}
+
+// segmentsMeet reports whether the segments a1-a2 and b1-b2 cross or
+// touch.
+func segmentsMeet(a1, a2, b1, b2 Point) bool {
+	// Each segment's ends must lie on opposite sides of the other's line.
+	d1 := cross(b2.Sub(b1), a1.Sub(b1))
+	d2 := cross(b2.Sub(b1), a2.Sub(b1))
+	d3 := cross(a2.Sub(a1), b1.Sub(a1))
+	d4 := cross(a2.Sub(a1), b2.Sub(a1))
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	// Otherwise they meet only if an end of one touches the other.
+	return onSegment(a1, b1, b2) || onSegment(a2, b1, b2) || onSegment(b1, a1, a2) || onSegment(b2, a1, a2)
+}
@@ -0,0 +1,121 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// The names shapes are written to JSON with.
+const (
+	kindCircle    = "circle"
+	kindRectangle = "rectangle"
+	kindTriangle  = "triangle"
+	kindPolygon   = "polygon"
+)
+
+// withKind writes v, a struct, as a JSON object with a leading "type"
+// field.
+func withKind(kind string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `{"type":%q,`, kind)
+	b.Write(data[1:])
+	return b.Bytes(), nil
+}
+
+// The plain types have the fields of the shapes but not their MarshalJSON
+// methods, so that the methods can marshal them without calling
+// themselves.
+type (
+	plainCircle    Circle
+	plainRectangle Rectangle
+	plainTriangle  Triangle
+	plainPolygon   Polygon
+)
+
+// MarshalJSON writes c as {"type":"circle","center":{"x":0,"y":0},"radius":1}.
+func (c Circle) MarshalJSON() ([]byte, error) { return withKind(kindCircle, plainCircle(c)) }
+
+// MarshalJSON writes r as {"type":"rectangle","min":{...},"max":{...}}.
+func (r Rectangle) MarshalJSON() ([]byte, error) { return withKind(kindRectangle, plainRectangle(r)) }
+
+// MarshalJSON writes t as {"type":"triangle","a":{...},"b":{...},"c":{...}}.
+func (t Triangle) MarshalJSON() ([]byte, error) { return withKind(kindTriangle, plainTriangle(t)) }
+
+// MarshalJSON writes p as {"type":"polygon","vertices":[...]}.
+func (p Polygon) MarshalJSON() ([]byte, error) { return withKind(kindPolygon, plainPolygon(p)) }
+
+// Unmarshal reads a shape written by one of the MarshalJSON methods,
+// choosing its Go type from the "type" field. It rejects shapes that no
+// constructor would make: a circle with a negative radius, a rectangle
+// whose Min is not below and to the left of its Max, and a polygon with
+// fewer than three vertices.
+func Unmarshal(data []byte) (Shape, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("geometry: %w", err)
+	}
+	var (
+		s   Shape
+		err error
+	)
+	switch head.Type {
+	case kindCircle:
+		var c Circle
+		if err = json.Unmarshal(data, &c); err == nil && !(c.Radius >= 0) {
+			err = fmt.Errorf("radius %g is negative", c.Radius)
+		}
+		s = c
+	case kindRectangle:
+		var r Rectangle
+		if err = json.Unmarshal(data, &r); err == nil && !(r.Min.X <= r.Max.X && r.Min.Y <= r.Max.Y) {
+			err = fmt.Errorf("min %v is not below and left of max %v", r.Min, r.Max)
+		}
+		s = r
+	case kindTriangle:
+		var t Triangle
+		err = json.Unmarshal(data, &t)
+		s = t
+	case kindPolygon:
+		var p Polygon
+		if err = json.Unmarshal(data, &p); err == nil {
+			p, err = NewPolygon(p.Vertices...)
+		}
+		s = p
+	case "":
+		return nil, fmt.Errorf("geometry: shape has no type: %s", data)
+	default:
+		return nil, fmt.Errorf("geometry: unknown shape type %q", head.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("geometry: reading %s: %w", head.Type, err)
+	}
+	return s, nil
+}
+
+// Shapes is a list of shapes of any kinds, which can be read from a JSON
+// array as well as written to one.
+type Shapes []Shape
+
+func (ss *Shapes) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("geometry: %w", err)
+	}
+	out := make(Shapes, len(raw))
+	for i, r := range raw {
+		s, err := Unmarshal(r)
+		if err != nil {
+			return fmt.Errorf("%w (shape %d)", err, i)
+		}
+		out[i] = s
+	}
+	*ss = out
+	return nil
+}
@@ -0,0 +1,257 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Circle is a circle with the given center and radius.
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+func (c Circle) Area() float64      { return math.Pi * c.Radius * c.Radius }
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+func (c Circle) Bounds() Rectangle {
+	r := Pt(c.Radius, c.Radius)
+	return Rectangle{c.Center.Sub(r), c.Center.Add(r)}
+}
+
+func (c Circle) Contains(p Point) bool { return c.Center.Dist(p) <= c.Radius }
+
+func (c Circle) Translate(d Point) Shape { return Circle{c.Center.Add(d), c.Radius} }
+
+func (c Circle) Scale(f float64, about Point) Shape {
+	return Circle{c.Center.scale(f, about), c.Radius * math.Abs(f)}
+}
+
+func (c Circle) Rotate(theta float64, about Point) Shape {
+	return Circle{c.Center.Rotate(theta, about), c.Radius}
+}
+
+func (c Circle) String() string { return fmt.Sprintf("Circle(%v, r=%g)", c.Center, c.Radius) }
+
+// Rectangle is an axis-aligned rectangle from Min to Max, which includes
+// both. It is well-formed if Min.X <= Max.X and Min.Y <= Max.Y; Rect makes
+// one from any two corners.
+//
+// It is also the type of bounding boxes, as image.Rectangle is in the
+// standard library.
+type Rectangle struct {
+	Min Point `json:"min"`
+	Max Point `json:"max"`
+}
+
+// Rect returns the rectangle with corners (x0, y0) and (x1, y1), in any
+// order.
+func Rect(x0, y0, x1, y1 float64) Rectangle {
+	return Rectangle{Pt(min(x0, x1), min(y0, y1)), Pt(max(x0, x1), max(y0, y1))}
+}
+
+func (r Rectangle) Width() float64  { return r.Max.X - r.Min.X }
+func (r Rectangle) Height() float64 { return r.Max.Y - r.Min.Y }
+
+// Center returns the point in the middle of r.
+func (r Rectangle) Center() Point { return r.Min.Add(r.Max).Mul(0.5) }
+
+func (r Rectangle) Area() float64      { return r.Width() * r.Height() }
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width() + r.Height()) }
+func (r Rectangle) Bounds() Rectangle  { return r }
+
+func (r Rectangle) Contains(p Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+// Overlaps reports whether r and s have at least one point in common.
+func (r Rectangle) Overlaps(s Rectangle) bool {
+	return r.Min.X <= s.Max.X && s.Min.X <= r.Max.X && r.Min.Y <= s.Max.Y && s.Min.Y <= r.Max.Y
+}
+
+// Union returns the smallest rectangle holding both r and s.
+func (r Rectangle) Union(s Rectangle) Rectangle {
+	return Rectangle{
+		Pt(min(r.Min.X, s.Min.X), min(r.Min.Y, s.Min.Y)),
+		Pt(max(r.Max.X, s.Max.X), max(r.Max.Y, s.Max.Y)),
+	}
+}
+
+// Polygon returns r's corners as a Polygon, anticlockwise from Min.
+func (r Rectangle) Polygon() Polygon {
+	return Polygon{[]Point{r.Min, Pt(r.Max.X, r.Min.Y), r.Max, Pt(r.Min.X, r.Max.Y)}}
+}
+
+func (r Rectangle) Translate(d Point) Shape { return Rectangle{r.Min.Add(d), r.Max.Add(d)} }
+
+func (r Rectangle) Scale(f float64, about Point) Shape {
+	a, b := r.Min.scale(f, about), r.Max.scale(f, about)
+	return Rect(a.X, a.Y, b.X, b.Y)
+}
+
+// Rotate returns a Polygon, as r turned by anything but a multiple of a
+// right angle is no longer axis-aligned.
+func (r Rectangle) Rotate(theta float64, about Point) Shape {
+	return r.Polygon().Rotate(theta, about)
+}
+
+func (r Rectangle) String() string { return fmt.Sprintf("Rectangle(%v-%v)", r.Min, r.Max) }
+
+// Triangle is the triangle with corners A, B and C.
+type Triangle struct {
+	A Point `json:"a"`
+	B Point `json:"b"`
+	C Point `json:"c"`
+}
+
+// TriangleFromSides places a triangle with sides of the given lengths, as
+// chapter 10's Triangle describes one: A at the origin, B along the
+// positive x axis and C above it, so that a is the length of BC, b of CA
+// and c of AB. It reports an error if no such triangle exists.
+func TriangleFromSides(a, b, c float64) (Triangle, error) {
+	if !(a > 0 && b > 0 && c > 0) {
+		return Triangle{}, fmt.Errorf("geometry: sides %g, %g, %g are not all positive", a, b, c)
+	}
+	if a+b < c || b+c < a || c+a < b {
+		return Triangle{}, fmt.Errorf("geometry: sides %g, %g, %g break the triangle inequality", a, b, c)
+	}
+	// C lies b from A and a from B; subtracting the two circle equations
+	// gives its x.
+	x := (b*b + c*c - a*a) / (2 * c)
+	y := math.Sqrt(max(0, b*b-x*x))
+	return Triangle{Pt(0, 0), Pt(c, 0), Pt(x, y)}, nil
+}
+
+// Polygon returns t's corners as a Polygon.
+func (t Triangle) Polygon() Polygon { return Polygon{[]Point{t.A, t.B, t.C}} }
+
+func (t Triangle) Area() float64         { return math.Abs(cross(t.B.Sub(t.A), t.C.Sub(t.A))) / 2 }
+func (t Triangle) Perimeter() float64    { return t.A.Dist(t.B) + t.B.Dist(t.C) + t.C.Dist(t.A) }
+func (t Triangle) Bounds() Rectangle     { return t.Polygon().Bounds() }
+func (t Triangle) Contains(p Point) bool { return t.Polygon().Contains(p) }
+
+func (t Triangle) Translate(d Point) Shape {
+	return t.transform(func(p Point) Point { return p.Add(d) })
+}
+
+func (t Triangle) Scale(f float64, about Point) Shape {
+	return t.transform(func(p Point) Point { return p.scale(f, about) })
+}
+
+func (t Triangle) Rotate(theta float64, about Point) Shape {
+	return t.transform(func(p Point) Point { return p.Rotate(theta, about) })
+}
+
+func (t Triangle) transform(f func(Point) Point) Triangle { return Triangle{f(t.A), f(t.B), f(t.C)} }
+
+func (t Triangle) String() string { return fmt.Sprintf("Triangle(%v, %v, %v)", t.A, t.B, t.C) }
+
+// Polygon is a simple polygon: its edges join each vertex to the next and
+// the last back to the first, and must not cross each other. The vertices
+// may run either way round.
+type Polygon struct {
+	Vertices []Point `json:"vertices"`
+}
+
+// NewPolygon returns the polygon with the given vertices, or an error if
+// there are fewer than three.
+func NewPolygon(vertices ...Point) (Polygon, error) {
+	if len(vertices) < 3 {
+		return Polygon{}, errors.New("geometry: a polygon needs at least 3 vertices")
+	}
+	return Polygon{vertices}, nil
+}
+
+// RegularPolygon returns the polygon with n equal sides whose vertices lie
+// on c, starting from the one straight to the right of its center.
+func RegularPolygon(n int, c Circle) Polygon {
+	if n < 3 {
+		panic("geometry: a regular polygon needs at least 3 sides")
+	}
+	vs := make([]Point, n)
+	for i := range vs {
+		vs[i] = c.Center.Add(Pt(c.Radius, 0)).Rotate(2*math.Pi*float64(i)/float64(n), c.Center)
+	}
+	return Polygon{vs}
+}
+
+// edges calls f with each edge of p.
+func (p Polygon) edges(f func(a, b Point) bool) {
+	for i, a := range p.Vertices {
+		if !f(a, p.Vertices[(i+1)%len(p.Vertices)]) {
+			return
+		}
+	}
+}
+
+// Area uses the shoelace formula: the sum of cross products of each pair
+// of neighbouring vertices is twice the area, signed by the direction the
+// vertices run.
+func (p Polygon) Area() float64 {
+	sum := 0.0
+	p.edges(func(a, b Point) bool {
+		sum += cross(a, b)
+		return true
+	})
+	return math.Abs(sum) / 2
+}
+
+func (p Polygon) Perimeter() float64 {
+	sum := 0.0
+	p.edges(func(a, b Point) bool {
+		sum += a.Dist(b)
+		return true
+	})
+	return sum
+}
+
+func (p Polygon) Bounds() Rectangle {
+	if len(p.Vertices) == 0 {
+		return Rectangle{}
+	}
+	r := Rectangle{p.Vertices[0], p.Vertices[0]}
+	for _, v := range p.Vertices[1:] {
+		r = r.Union(Rectangle{v, v})
+	}
+	return r
+}
+
+// Contains casts a ray from q to the right and counts the edges it
+// crosses: an odd number means q is inside. Points on an edge are inside.
+func (p Polygon) Contains(q Point) bool {
+	inside := false
+	p.edges(func(a, b Point) bool {
+		if onSegment(q, a, b) {
+			inside = true
+			return false
+		}
+		if (a.Y > q.Y) != (b.Y > q.Y) && q.X < a.X+(q.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+		return true
+	})
+	return inside
+}
+
+func (p Polygon) Translate(d Point) Shape {
+	return p.transform(func(q Point) Point { return q.Add(d) })
+}
+
+func (p Polygon) Scale(f float64, about Point) Shape {
+	return p.transform(func(q Point) Point { return q.scale(f, about) })
+}
+
+func (p Polygon) Rotate(theta float64, about Point) Shape {
+	return p.transform(func(q Point) Point { return q.Rotate(theta, about) })
+}
+
+func (p Polygon) transform(f func(Point) Point) Polygon {
+	vs := make([]Point, len(p.Vertices))
+	for i, v := range p.Vertices {
+		vs[i] = f(v)
+	}
+	return Polygon{vs}
+}
+
+func (p Polygon) String() string { return fmt.Sprintf("Polygon%v", p.Vertices) }
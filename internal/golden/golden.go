@@ -0,0 +1,163 @@
+// Package golden compares the printed output of chapter examples with
+// checked-in transcripts.
+//
+// Each chapter package has a golden_test.go that calls TestChapter. The
+// transcripts live in the chapter's testdata/golden directory, one file per
+// section plus Main.golden for the whole chapter. Refresh them with
+//
+//	go test ./go_tutorial/... -update
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sumit-covlant/go_tutorial/internal/tutor"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Options tunes how a chapter's transcripts are compared.
+type Options struct {
+	// Skip maps a section name (or "Main") to the reason it is not checked.
+	Skip map[string]string
+	// Unordered names sections whose lines come out in a varying order,
+	// for example because they range over a map. Their lines are sorted
+	// before comparing.
+	Unordered []string
+	// Run, if set, is called to run each section instead of calling it
+	// directly, for example to run it against a fake clock.
+	Run func(t *testing.T, section func())
+}
+
+// Capture runs fn and returns everything it wrote to os.Stdout.
+func Capture(fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		r.Close()
+		done <- data
+	}()
+
+	fn()
+	w.Close()
+	return string(<-done)
+}
+
+var (
+	// %p and %v of pointers, e.g. 0xc000012345.
+	addressPattern = regexp.MustCompile(`0x[0-9a-f]{6,}`)
+	// time.Time's String form, and the "2006-01-02 15:04:05" layout.
+	timePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(\.\d+)?( [+-]\d{4} [A-Z]+)?( m=[+-]\d+\.\d+)?`)
+	// Absolute source paths, e.g. from runtime.Caller.
+	sourcePathPattern = regexp.MustCompile(`\S*/([^/\s]+\.go:\d+)`)
+)
+
+// Normalize replaces the parts of a transcript that change from run to run
+// (memory addresses, timestamps and absolute source paths) with stable
+// placeholders.
+func Normalize(s string) string {
+	s = addressPattern.ReplaceAllString(s, "0xADDR")
+	s = timePattern.ReplaceAllString(s, "TIMESTAMP")
+	s = sourcePathPattern.ReplaceAllString(s, "$1")
+	return s
+}
+
+// Check compares got with the golden file at path, or rewrites the file
+// when the -update flag is set.
+func Check(t testing.TB, path, got string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run go test -update to accept it)\n%s", path, diff(string(want), got))
+	}
+}
+
+// TestChapter checks the output of the chapter's Main and of each of its
+// sections against testdata/golden/<name>.golden.
+func TestChapter(t *testing.T, c tutor.Chapter, opts Options) {
+	check := func(name string, run func()) {
+		t.Run(name, func(t *testing.T) {
+			if reason, ok := opts.Skip[name]; ok {
+				t.Skip(reason)
+			}
+			section := run
+			if opts.Run != nil {
+				section = func() { opts.Run(t, run) }
+			}
+			got := Normalize(Capture(section))
+			if slices.Contains(opts.Unordered, name) {
+				got = sortLines(got)
+			}
+			Check(t, filepath.Join("testdata", "golden", name+".golden"), got)
+		})
+	}
+
+	check("Main", c.Main)
+	for _, s := range c.Sections {
+		check(s.Name, s.Run)
+	}
+}
+
+func sortLines(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	slices.Sort(lines)
+	return strings.Join(lines, "")
+}
+
+// diff returns a short line-by-line report of where want and got first
+// disagree.
+func diff(want, got string) string {
+	wl := strings.Split(want, "\n")
+	gl := strings.Split(got, "\n")
+
+	var b bytes.Buffer
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			fmt.Fprintf(&b, "line %d:\n  want: %s\n  got:  %s\n", i+1, w, g)
+			if b.Len() > 2000 {
+				b.WriteString("  ...\n")
+				break
+			}
+		}
+	}
+	return b.String()
+}
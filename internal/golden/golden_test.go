@@ -0,0 +1,28 @@
+package golden
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"ptr: 0xc000012345\n", "ptr: 0xADDR\n"},
+		{"small: 0x1f\n", "small: 0x1f\n"},
+		{"at 2024-01-02 15:04:05\n", "at TIMESTAMP\n"},
+		{"now 2024-01-02 15:04:05.123456789 +0000 UTC m=+0.001234567\n", "now TIMESTAMP\n"},
+		{"rfc3339 2024-01-02T15:04:05\n", "rfc3339 TIMESTAMP\n"},
+		{"called from /home/ana/go_tutorial/ch11/errors.go:42\n", "called from errors.go:42\n"},
+		{"plain text, 2024 and 15:04\n", "plain text, 2024 and 15:04\n"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSortLines(t *testing.T) {
+	if got, want := sortLines("b\na\nc\n"), "a\nb\nc\n"; got != want {
+		t.Errorf("sortLines = %q, want %q", got, want)
+	}
+}
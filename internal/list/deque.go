@@ -0,0 +1,121 @@
+package list
+
+import "iter"
+
+// Deque is a double-ended queue: values can be added and removed at both
+// ends in constant amortized time. It keeps them in a ring buffer, a slice
+// used circularly, which doubles when full. The zero value is an empty
+// deque.
+//
+// Unlike a linked list it makes no allocation per value, and its values
+// sit next to each other in memory, which makes it the faster choice for
+// queues and stacks.
+type Deque[T any] struct {
+	buf  []T // its length is a power of two
+	head int // index of the front value in buf
+	len  int
+}
+
+// Len returns the number of values in d.
+func (d *Deque[T]) Len() int { return d.len }
+
+// grow makes room for one more value.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(8, 2*len(d.buf)))
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+// index returns the position in buf of the ith value. As len(buf) is a
+// power of two, masking wraps around more cheaply than %.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// PushBack adds v at the back of d.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.len)] = v
+	d.len++
+}
+
+// PushFront adds v at the front of d.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(-1 + len(d.buf))
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes the front value of d and returns it. ok is false if d
+// is empty.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	var zero T
+	v, d.buf[d.head] = d.buf[d.head], zero // let the GC have it
+	d.head = d.index(1)
+	d.len--
+	return v, true
+}
+
+// PopBack removes the back value of d and returns it. ok is false if d is
+// empty.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	var zero T
+	i := d.index(d.len - 1)
+	v, d.buf[i] = d.buf[i], zero
+	d.len--
+	return v, true
+}
+
+// Front returns the front value of d. ok is false if d is empty.
+func (d *Deque[T]) Front() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back value of d. ok is false if d is empty.
+func (d *Deque[T]) Back() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// At returns the ith value of d, counting from the front. It panics if i
+// is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.len {
+		panic("list: Deque index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// All returns an iterator over the values of d from front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// The values run from head to the end of buf, then wrap around.
+		end := min(d.head+d.len, len(d.buf))
+		for _, v := range d.buf[d.head:end] {
+			if !yield(v) {
+				return
+			}
+		}
+		for _, v := range d.buf[:d.len-(end-d.head)] {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
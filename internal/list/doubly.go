@@ -0,0 +1,221 @@
+package list
+
+import (
+	"iter"
+)
+
+// Element is an element of a Doubly linked list.
+type Element[T any] struct {
+	Value      T
+	next, prev *Element[T]
+	list       *Doubly[T]
+}
+
+// Next returns the element after e, or nil at the back of the list.
+func (e *Element[T]) Next() *Element[T] {
+	if e.list == nil || e.next == &e.list.root {
+		return nil
+	}
+	return e.next
+}
+
+// Prev returns the element before e, or nil at the front of the list.
+func (e *Element[T]) Prev() *Element[T] {
+	if e.list == nil || e.prev == &e.list.root {
+		return nil
+	}
+	return e.prev
+}
+
+// Doubly is a doubly linked list. The zero value is an empty list.
+//
+// Like container/list it keeps a sentinel element, root, linked to both
+// the front and the back, so inserting and removing never have to check
+// for nil neighbours.
+type Doubly[T any] struct {
+	root Element[T]
+	len  int
+}
+
+// NewDoubly returns a list of the values.
+func NewDoubly[T any](values ...T) *Doubly[T] {
+	l := new(Doubly[T])
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func (l *Doubly[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next, l.root.prev = &l.root, &l.root
+	}
+}
+
+// Len returns the number of elements in l.
+func (l *Doubly[T]) Len() int { return l.len }
+
+// Front returns the first element of l, or nil if l is empty.
+func (l *Doubly[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of l, or nil if l is empty.
+func (l *Doubly[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// link puts e just after at and returns it.
+func (l *Doubly[T]) link(e, at *Element[T]) *Element[T] {
+	e.prev, e.next = at, at.next
+	e.prev.next, e.next.prev = e, e
+	e.list = l
+	l.len++
+	return e
+}
+
+func (l *Doubly[T]) unlink(e *Element[T]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+	e.next, e.prev, e.list = nil, nil, nil
+	l.len--
+}
+
+// PushFront adds v at the front of l and returns its element.
+func (l *Doubly[T]) PushFront(v T) *Element[T] {
+	l.lazyInit()
+	return l.link(&Element[T]{Value: v}, &l.root)
+}
+
+// PushBack adds v at the back of l and returns its element.
+func (l *Doubly[T]) PushBack(v T) *Element[T] {
+	l.lazyInit()
+	return l.link(&Element[T]{Value: v}, l.root.prev)
+}
+
+// InsertBefore adds v just before mark, which must be in l, and returns
+// the new element.
+func (l *Doubly[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	l.mustOwn(mark)
+	return l.link(&Element[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter adds v just after mark, which must be in l, and returns the
+// new element.
+func (l *Doubly[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	l.mustOwn(mark)
+	return l.link(&Element[T]{Value: v}, mark)
+}
+
+func (l *Doubly[T]) mustOwn(e *Element[T]) {
+	if e.list != l {
+		panic("list: element is not in this list")
+	}
+}
+
+// Remove removes e from l, if it is in l, and returns its value.
+func (l *Doubly[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		l.unlink(e)
+	}
+	return e.Value
+}
+
+// MoveToFront moves e, which must be in l, to the front of l.
+func (l *Doubly[T]) MoveToFront(e *Element[T]) {
+	l.mustOwn(e)
+	if l.root.next == e {
+		return
+	}
+	l.unlink(e)
+	l.link(e, &l.root)
+}
+
+// MoveToBack moves e, which must be in l, to the back of l.
+func (l *Doubly[T]) MoveToBack(e *Element[T]) {
+	l.mustOwn(e)
+	if l.root.prev == e {
+		return
+	}
+	l.unlink(e)
+	l.link(e, l.root.prev)
+}
+
+// Find returns the first element of l whose value satisfies match, or nil.
+func (l *Doubly[T]) Find(match func(T) bool) *Element[T] {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if match(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Delete removes the first value of l that satisfies match, and reports
+// whether there was one.
+func (l *Doubly[T]) Delete(match func(T) bool) bool {
+	if e := l.Find(match); e != nil {
+		l.unlink(e)
+		return true
+	}
+	return false
+}
+
+// Reverse reverses l in place by swapping each element's links. Elements
+// stay valid and in l.
+func (l *Doubly[T]) Reverse() {
+	l.lazyInit()
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		e = e.prev // the old next
+		if e == &l.root {
+			return
+		}
+	}
+}
+
+// Splice moves every element of other to the back of l, in time
+// proportional to other's length, since each element records its list.
+// other is left empty.
+func (l *Doubly[T]) Splice(other *Doubly[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+	l.lazyInit()
+	first, last := other.root.next, other.root.prev
+	for e := first; e != &other.root; e = e.next {
+		e.list = l
+	}
+	first.prev, last.next = l.root.prev, &l.root
+	l.root.prev.next, l.root.prev = first, last
+	l.len += other.len
+	other.root.next, other.root.prev, other.len = &other.root, &other.root, 0
+}
+
+// All returns an iterator over the values of l from front to back.
+func (l *Doubly[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of l from back to front.
+func (l *Doubly[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.Back(); e != nil; e = e.Prev() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package list
+
+import (
+	"container/list"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestNode(t *testing.T) {
+	head := FromValues(1, 2, 3)
+	if got := head.String(); got != "1 -> 2 -> 3 -> nil" {
+		t.Errorf("String() = %q", got)
+	}
+	head = Reverse(head)
+	if got := slices.Collect(head.Values()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("reversed to %v", got)
+	}
+	if FromValues[int]() != nil || Reverse[int](nil) != nil {
+		t.Error("empty chain is not nil")
+	}
+	var empty *Node[string]
+	if got := empty.String(); got != "nil" {
+		t.Errorf("nil String() = %q", got)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	for n := 1; n <= 6; n++ {
+		for start := -1; start < n; start++ {
+			var nodes []*Node[int]
+			for i := range n {
+				nodes = append(nodes, &Node[int]{Value: i})
+				if i > 0 {
+					nodes[i-1].Next = nodes[i]
+				}
+			}
+			var want *Node[int]
+			if start >= 0 {
+				want = nodes[start]
+				nodes[n-1].Next = want
+			}
+			if got := CycleStart(nodes[0]); got != want {
+				t.Errorf("n=%d: CycleStart found %v, want node %d", n, got, start)
+			}
+			if HasCycle(nodes[0]) != (start >= 0) {
+				t.Errorf("n=%d start=%d: HasCycle wrong", n, start)
+			}
+		}
+	}
+
+	loop := FromValues(1, 2, 3)
+	loop.Next.Next.Next = loop.Next
+	if got := loop.String(); got != "1 -> 2 -> 3 -> ..." {
+		t.Errorf("String() of a cycle = %q", got)
+	}
+}
+
+func TestSingly(t *testing.T) {
+	l := NewSingly(2, 3)
+	l.PushFront(1)
+	l.PushBack(5)
+	l.InsertAfter(l.Find(func(v int) bool { return v == 3 }), 4)
+	check := func(want ...int) {
+		t.Helper()
+		if got := slices.Collect(l.All()); !slices.Equal(got, want) || l.Len() != len(want) {
+			t.Errorf("list is %v (len %d), want %v", got, l.Len(), want)
+		}
+	}
+	check(1, 2, 3, 4, 5)
+
+	if !l.Delete(func(v int) bool { return v == 5 }) || l.Delete(func(v int) bool { return v == 9 }) {
+		t.Error("Delete reported wrongly")
+	}
+	l.PushBack(6) // the tail must have moved back to 4
+	check(1, 2, 3, 4, 6)
+
+	l.Reverse()
+	l.PushBack(0)
+	check(6, 4, 3, 2, 1, 0)
+
+	other := NewSingly(-1, -2)
+	l.Splice(other)
+	check(6, 4, 3, 2, 1, 0, -1, -2)
+	if other.Len() != 0 || other.Front() != nil {
+		t.Error("Splice left other non-empty")
+	}
+	other.PushBack(7)
+	if l.Len() != 8 {
+		t.Error("other still shares nodes with l")
+	}
+
+	for range 8 {
+		l.PopFront()
+	}
+	if _, ok := l.PopFront(); ok || l.Len() != 0 {
+		t.Error("PopFront on an empty list")
+	}
+	l.PushBack(1)
+	check(1)
+	if got := l.String(); got != "1 -> nil" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDoubly(t *testing.T) {
+	var l Doubly[string]
+	b := l.PushBack("b")
+	l.PushFront("a")
+	d := l.PushBack("d")
+	l.InsertBefore("c", d)
+	l.InsertAfter("e", d)
+	check := func(want ...string) {
+		t.Helper()
+		got := slices.Collect(l.All())
+		back := slices.Collect(l.Backward())
+		slices.Reverse(back)
+		if !slices.Equal(got, want) || !slices.Equal(back, want) || l.Len() != len(want) {
+			t.Errorf("list is %v, backwards %v (len %d), want %v", got, back, l.Len(), want)
+		}
+	}
+	check("a", "b", "c", "d", "e")
+
+	if v := l.Remove(b); v != "b" {
+		t.Errorf("Remove returned %q", v)
+	}
+	l.Remove(b) // no longer in l
+	check("a", "c", "d", "e")
+
+	l.MoveToFront(d)
+	l.MoveToBack(l.Find(func(s string) bool { return s == "a" }))
+	check("d", "c", "e", "a")
+
+	l.Reverse()
+	check("a", "e", "c", "d")
+	if d.Next() != nil || d.Prev().Value != "c" {
+		t.Error("element links wrong after Reverse")
+	}
+
+	other := NewDoubly("x", "y")
+	x := other.Front()
+	l.Splice(other)
+	check("a", "e", "c", "d", "x", "y")
+	l.Remove(x) // x now belongs to l
+	check("a", "e", "c", "d", "y")
+	if other.Len() != 0 || other.Front() != nil {
+		t.Error("Splice left other non-empty")
+	}
+
+	if !l.Delete(func(s string) bool { return s == "y" }) {
+		t.Error("Delete found nothing")
+	}
+	check("a", "e", "c", "d")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("InsertAfter with a foreign element did not panic")
+		}
+	}()
+	l.InsertAfter("z", x)
+}
+
+// TestDequeModel checks a Deque against a slice doing the same operations.
+func TestDequeModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var d Deque[int]
+	var model []int
+	for i := range 5000 {
+		switch r.IntN(4) {
+		case 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case 1:
+			d.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case 2:
+			v, ok := d.PopFront()
+			if ok != (len(model) > 0) || (ok && v != model[0]) {
+				t.Fatalf("step %d: PopFront = %d, %t; model %v", i, v, ok, model)
+			}
+			if ok {
+				model = model[1:]
+			}
+		case 3:
+			v, ok := d.PopBack()
+			if ok != (len(model) > 0) || (ok && v != model[len(model)-1]) {
+				t.Fatalf("step %d: PopBack = %d, %t; model %v", i, v, ok, model)
+			}
+			if ok {
+				model = model[:len(model)-1]
+			}
+		}
+		if d.Len() != len(model) {
+			t.Fatalf("step %d: Len() = %d, want %d", i, d.Len(), len(model))
+		}
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, model) {
+		t.Errorf("All() = %v, want %v", got, model)
+	}
+	for i, v := range model {
+		if d.At(i) != v {
+			t.Fatalf("At(%d) = %d, want %d", i, d.At(i), v)
+		}
+	}
+	if f, _ := d.Front(); len(model) > 0 && f != model[0] {
+		t.Errorf("Front() = %d", f)
+	}
+	if b, _ := d.Back(); len(model) > 0 && b != model[len(model)-1] {
+		t.Errorf("Back() = %d", b)
+	}
+}
+
+// The benchmarks run the same first-in, first-out traffic through each
+// structure: push n values, then alternate pushes and pops.
+
+const queueSize = 1000
+
+func BenchmarkQueue(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		var q Deque[int]
+		for i := range queueSize {
+			q.PushBack(i)
+		}
+		for i := range b.N {
+			q.PushBack(i)
+			q.PopFront()
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		var q []int
+		for i := range queueSize {
+			q = append(q, i)
+		}
+		for i := range b.N {
+			q = append(q, i)
+			q = q[1:] // the backing array is reallocated as it creeps forward
+		}
+	})
+	b.Run("Doubly", func(b *testing.B) {
+		var q Doubly[int]
+		for i := range queueSize {
+			q.PushBack(i)
+		}
+		for i := range b.N {
+			q.PushBack(i)
+			q.Remove(q.Front())
+		}
+	})
+	b.Run("Singly", func(b *testing.B) {
+		var q Singly[int]
+		for i := range queueSize {
+			q.PushBack(i)
+		}
+		for i := range b.N {
+			q.PushBack(i)
+			q.PopFront()
+		}
+	})
+	b.Run("container/list", func(b *testing.B) {
+		q := list.New()
+		for i := range queueSize {
+			q.PushBack(i)
+		}
+		for i := range b.N {
+			q.PushBack(i)
+			q.Remove(q.Front())
+		}
+	})
+}
+
+func BenchmarkIterate(b *testing.B) {
+	values := make([]int, queueSize)
+	for i := range values {
+		values[i] = i
+	}
+	var d Deque[int]
+	for _, v := range values {
+		d.PushBack(v)
+	}
+	dl := NewDoubly(values...)
+	cl := list.New()
+	for _, v := range values {
+		cl.PushBack(v)
+	}
+	sum := func(seq func(func(int) bool)) int {
+		n := 0
+		for v := range seq {
+			n += v
+		}
+		return n
+	}
+	for _, bm := range []struct {
+		name string
+		run  func() int
+	}{
+		{"Slice", func() int { return sum(slices.Values(values)) }},
+		{"Deque", func() int { return sum(d.All()) }},
+		{"Doubly", func() int { return sum(dl.All()) }},
+		{"container/list", func() int {
+			n := 0
+			for e := cl.Front(); e != nil; e = e.Next() {
+				n += e.Value.(int)
+			}
+			return n
+		}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for range b.N {
+				if got := bm.run(); got != queueSize*(queueSize-1)/2 {
+					b.Fatal(fmt.Sprint("sum ", got))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Package list has generic linked lists and a double-ended queue.
+//
+// Node is the pointers chapter's Node made generic, and the functions on
+// it (Reverse, HasCycle, CycleStart) work on any chain of Nodes, however it
+// was built. Singly keeps such a chain together with its length and last
+// node, so that adding at either end takes constant time. Doubly is linked
+// both ways, like container/list, so an element can be removed or moved
+// without a search. Deque is a ring buffer, which is usually the faster
+// choice for a queue or stack; the benchmarks in the tests compare them.
+package list
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Node is a node of a singly linked list.
+type Node[T any] struct {
+	Value T
+	Next  *Node[T]
+}
+
+// FromValues links the values into a chain of Nodes and returns its head,
+// or nil if there are no values.
+func FromValues[T any](values ...T) *Node[T] {
+	var head *Node[T]
+	for i := len(values) - 1; i >= 0; i-- {
+		head = &Node[T]{Value: values[i], Next: head}
+	}
+	return head
+}
+
+// Values returns an iterator over the values from n to the end of its
+// chain. On a chain with a cycle it never ends by itself.
+func (n *Node[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ; n != nil; n = n.Next {
+			if !yield(n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// String formats the chain from n as "1 -> 2 -> 3 -> nil", as the
+// pointers chapter's printList does. A cycle is shown as "...", after the
+// node that closes it.
+func (n *Node[T]) String() string {
+	var b strings.Builder
+	stop := CycleStart(n)
+	seen := false
+	for ; n != nil; n = n.Next {
+		if n == stop {
+			if seen {
+				b.WriteString("...")
+				return b.String()
+			}
+			seen = true
+		}
+		fmt.Fprintf(&b, "%v -> ", n.Value)
+	}
+	b.WriteString("nil")
+	return b.String()
+}
+
+// Reverse reverses the chain starting at head in place, by turning each
+// Next pointer around, and returns the new head. The chain must not have
+// a cycle.
+func Reverse[T any](head *Node[T]) *Node[T] {
+	var prev *Node[T]
+	for head != nil {
+		head.Next, prev, head = prev, head, head.Next
+	}
+	return prev
+}
+
+// HasCycle reports whether following Next from head ever comes back to a
+// node already passed, instead of reaching nil.
+func HasCycle[T any](head *Node[T]) bool {
+	return CycleStart(head) != nil
+}
+
+// CycleStart returns the first node of the chain from head that is part
+// of a cycle, or nil if the chain ends. It uses Floyd's tortoise and hare:
+// one pointer steps one node at a time and another two, and they can only
+// meet inside a cycle. It takes linear time and no extra memory.
+func CycleStart[T any](head *Node[T]) *Node[T] {
+	slow, fast := head, head
+	for fast != nil && fast.Next != nil {
+		slow, fast = slow.Next, fast.Next.Next
+		if slow == fast {
+			// The distance from head to the start of the cycle equals the
+			// distance from the meeting point onwards to it.
+			for slow = head; slow != fast; slow, fast = slow.Next, fast.Next {
+			}
+			return slow
+		}
+	}
+	return nil
+}
+
+// Singly is a singly linked list. The zero value is an empty list.
+//
+// Its nodes may be read, and their values changed, through Front and
+// Node.Next, but relinking them directly breaks the list.
+type Singly[T any] struct {
+	head, tail *Node[T]
+	len        int
+}
+
+// NewSingly returns a list of the values.
+func NewSingly[T any](values ...T) *Singly[T] {
+	l := new(Singly[T])
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// Len returns the number of values in l.
+func (l *Singly[T]) Len() int { return l.len }
+
+// Front returns the first node of l, or nil if l is empty.
+func (l *Singly[T]) Front() *Node[T] { return l.head }
+
+// PushFront adds v at the front of l and returns its node.
+func (l *Singly[T]) PushFront(v T) *Node[T] {
+	n := &Node[T]{Value: v, Next: l.head}
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+	l.len++
+	return n
+}
+
+// PushBack adds v at the back of l and returns its node.
+func (l *Singly[T]) PushBack(v T) *Node[T] {
+	if l.tail == nil {
+		return l.PushFront(v)
+	}
+	n := &Node[T]{Value: v}
+	l.tail.Next = n
+	l.tail = n
+	l.len++
+	return n
+}
+
+// InsertAfter adds v just after the node at, which must be in l, and
+// returns the new node.
+func (l *Singly[T]) InsertAfter(at *Node[T], v T) *Node[T] {
+	n := &Node[T]{Value: v, Next: at.Next}
+	at.Next = n
+	if l.tail == at {
+		l.tail = n
+	}
+	l.len++
+	return n
+}
+
+// PopFront removes the first value of l and returns it. ok is false if l
+// is empty.
+func (l *Singly[T]) PopFront() (v T, ok bool) {
+	if l.head == nil {
+		return v, false
+	}
+	n := l.head
+	l.head = n.Next
+	if l.head == nil {
+		l.tail = nil
+	}
+	n.Next = nil
+	l.len--
+	return n.Value, true
+}
+
+// Find returns the first node of l whose value satisfies match, or nil.
+func (l *Singly[T]) Find(match func(T) bool) *Node[T] {
+	for n := l.head; n != nil; n = n.Next {
+		if match(n.Value) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Delete removes the first value of l that satisfies match, and reports
+// whether there was one. Without a link back, it has to walk the list to
+// find the node before.
+func (l *Singly[T]) Delete(match func(T) bool) bool {
+	var prev *Node[T]
+	for n := l.head; n != nil; prev, n = n, n.Next {
+		if !match(n.Value) {
+			continue
+		}
+		if prev == nil {
+			l.head = n.Next
+		} else {
+			prev.Next = n.Next
+		}
+		if l.tail == n {
+			l.tail = prev
+		}
+		n.Next = nil
+		l.len--
+		return true
+	}
+	return false
+}
+
+// Reverse reverses l in place.
+func (l *Singly[T]) Reverse() {
+	l.tail = l.head
+	l.head = Reverse(l.head)
+}
+
+// Splice moves every node of other to the back of l, in constant time,
+// leaving other empty.
+func (l *Singly[T]) Splice(other *Singly[T]) {
+	if other == l || other.head == nil {
+		return
+	}
+	if l.tail == nil {
+		l.head = other.head
+	} else {
+		l.tail.Next = other.head
+	}
+	l.tail = other.tail
+	l.len += other.len
+	*other = Singly[T]{}
+}
+
+// All returns an iterator over the values of l from front to back.
+func (l *Singly[T]) All() iter.Seq[T] { return l.head.Values() }
+
+// String formats l as "1 -> 2 -> 3 -> nil".
+func (l *Singly[T]) String() string { return l.head.String() }
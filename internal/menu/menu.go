@@ -0,0 +1,186 @@
+// Package menu runs numbered terminal menus: a Menu lists commands, each
+// with a handler, reads choices line by line and runs them, and can hold
+// submenus.
+//
+// The control structures chapter's menu example is built on it. Where that
+// chapter's showMenu and processChoice hard-code four choices in a switch,
+// a Menu is assembled at run time, and RunScript plays a fixed list of
+// inputs so a session can be tested or shown in a transcript.
+package menu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Handler runs a command. args are the words typed after the command's
+// name or number. Output goes to w. An error is shown to the user and the
+// menu carries on, unless it is ErrExit.
+type Handler func(w io.Writer, args []string) error
+
+// ErrExit may be returned by a Handler to end the session.
+var ErrExit = errors.New("menu: exit")
+
+// Words that every menu understands, which no command may be named.
+var builtins = map[string]bool{"help": true, "?": true, "back": true, "exit": true, "quit": true}
+
+// Menu is a list of commands. The zero value is an empty menu with no
+// title.
+type Menu struct {
+	Title string
+	items []*item
+}
+
+type item struct {
+	name, help string
+	run        Handler
+	sub        *Menu
+}
+
+// New returns an empty menu with the given title.
+func New(title string) *Menu { return &Menu{Title: title} }
+
+// Add adds a command, which is chosen by typing its name or its number.
+// Names are single words and are not case sensitive. Add panics if the
+// name is empty, has spaces, is a number, is taken, or is one of help, ?,
+// back, exit and quit.
+func (m *Menu) Add(name, help string, run Handler) {
+	m.add(&item{name: name, help: help, run: run})
+}
+
+// Sub adds a command that opens a submenu, and returns the submenu for its
+// commands to be added to. Typing "back" in the submenu returns to m. Sub
+// panics on bad names as Add does.
+func (m *Menu) Sub(name, help string) *Menu {
+	sub := New(name)
+	m.add(&item{name: name, help: help, sub: sub})
+	return sub
+}
+
+func (m *Menu) add(it *item) {
+	name := strings.ToLower(it.name)
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool { return r == ' ' || r == '\t' }) {
+		panic(fmt.Sprintf("menu: bad command name %q", it.name))
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		panic(fmt.Sprintf("menu: command name %q is a number", it.name))
+	}
+	if builtins[name] || m.lookup(name) != nil {
+		panic(fmt.Sprintf("menu: command name %q is taken", it.name))
+	}
+	m.items = append(m.items, it)
+}
+
+// lookup finds the command named or numbered by word, or returns nil.
+func (m *Menu) lookup(word string) *item {
+	if n, err := strconv.Atoi(word); err == nil {
+		if n >= 1 && n <= len(m.items) {
+			return m.items[n-1]
+		}
+		return nil
+	}
+	for _, it := range m.items {
+		if strings.EqualFold(it.name, word) {
+			return it
+		}
+	}
+	return nil
+}
+
+// Help writes the numbered list of commands.
+func (m *Menu) Help(w io.Writer) {
+	if m.Title != "" {
+		fmt.Fprintln(w, m.Title)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, it := range m.items {
+		name := it.name
+		if it.sub != nil {
+			name += " >"
+		}
+		fmt.Fprintf(tw, "  %d.\t%s\t%s\n", i+1, name, it.help)
+	}
+	tw.Flush()
+}
+
+// Run shows the menu and runs the commands read from in, one per line,
+// until in ends or the user types exit or quit. Output, including the
+// prompt, goes to out. Run returns an error only if reading in fails.
+func (m *Menu) Run(in io.Reader, out io.Writer) error {
+	return m.run(in, out, false)
+}
+
+// RunScript runs the menu on a fixed list of input lines, writing each
+// line after the prompt as if it had been typed, so the output reads like
+// an interactive session.
+func (m *Menu) RunScript(out io.Writer, lines ...string) error {
+	return m.run(strings.NewReader(strings.Join(lines, "\n")), out, true)
+}
+
+func (m *Menu) run(in io.Reader, out io.Writer, echo bool) error {
+	stack := []*Menu{m}
+	m.Help(out)
+	sc := bufio.NewScanner(in)
+	for {
+		cur := stack[len(stack)-1]
+		fmt.Fprint(out, prompt(stack))
+		if !sc.Scan() {
+			fmt.Fprintln(out)
+			return sc.Err()
+		}
+		line := sc.Text()
+		if echo {
+			fmt.Fprintln(out, strings.TrimSpace(line))
+		}
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			continue
+		}
+		word, args := strings.ToLower(words[0]), words[1:]
+		switch word {
+		case "help", "?":
+			cur.Help(out)
+			continue
+		case "back":
+			if len(stack) == 1 {
+				fmt.Fprintln(out, "Already at the top menu")
+			} else {
+				stack = stack[:len(stack)-1]
+				stack[len(stack)-1].Help(out)
+			}
+			continue
+		case "exit", "quit":
+			return nil
+		}
+
+		it := cur.lookup(word)
+		switch {
+		case it == nil:
+			fmt.Fprintf(out, "Unknown command %q; type help to list them\n", words[0])
+		case it.sub != nil:
+			stack = append(stack, it.sub)
+			it.sub.Help(out)
+		default:
+			if err := it.run(out, args); errors.Is(err, ErrExit) {
+				return nil
+			} else if err != nil {
+				fmt.Fprintf(out, "Error: %v\n", err)
+			}
+		}
+	}
+}
+
+// prompt returns the prompt for the innermost menu of stack, such as
+// "settings/colors> ", naming each submenu on the way.
+func prompt(stack []*Menu) string {
+	var names []string
+	for _, m := range stack[1:] {
+		names = append(names, m.Title)
+	}
+	return strings.Join(names, "/") + "> "
+}
@@ -0,0 +1,124 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func newTestMenu(calls *[]string) *Menu {
+	m := New("Main")
+	m.Add("greet", "Say hello", func(w io.Writer, args []string) error {
+		*calls = append(*calls, "greet "+strings.Join(args, " "))
+		fmt.Fprintf(w, "hello %s\n", strings.Join(args, " "))
+		return nil
+	})
+	m.Add("fail", "Always fails", func(w io.Writer, args []string) error {
+		return errors.New("it broke")
+	})
+	settings := m.Sub("settings", "Change settings")
+	settings.Add("reset", "Reset everything", func(w io.Writer, args []string) error {
+		*calls = append(*calls, "reset")
+		return nil
+	})
+	settings.Add("stop", "Stop the session", func(w io.Writer, args []string) error {
+		return ErrExit
+	})
+	return m
+}
+
+func TestRunScript(t *testing.T) {
+	var calls []string
+	var out strings.Builder
+	err := newTestMenu(&calls).RunScript(&out,
+		"1 Ann",
+		"GREET  Bob ",
+		"fail",
+		"9",
+		"nope",
+		"back",
+		"settings",
+		"1",
+		"back",
+		"exit",
+		"greet never",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Main
+  1.  greet       Say hello
+  2.  fail        Always fails
+  3.  settings >  Change settings
+> 1 Ann
+hello Ann
+> GREET  Bob
+hello Bob
+> fail
+Error: it broke
+> 9
+Unknown command "9"; type help to list them
+> nope
+Unknown command "nope"; type help to list them
+> back
+Already at the top menu
+> settings
+settings
+  1.  reset  Reset everything
+  2.  stop   Stop the session
+settings> 1
+settings> back
+Main
+  1.  greet       Say hello
+  2.  fail        Always fails
+  3.  settings >  Change settings
+> exit
+`
+	if got := out.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+	if want := []string{"greet Ann", "greet Bob", "reset"}; strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestRunEndsAtEOF(t *testing.T) {
+	var calls []string
+	var out strings.Builder
+	if err := newTestMenu(&calls).Run(strings.NewReader("help\n\n  \nsettings\nstop\ngreet x\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("ran %q after ErrExit", calls)
+	}
+	if strings.Contains(out.String(), "help\n") {
+		t.Errorf("Run echoed its input:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := New("Empty").Run(strings.NewReader("help"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "Empty\n> Empty\n> \n"; got != want {
+		t.Errorf("output %q, want %q", got, want)
+	}
+}
+
+func TestAddPanics(t *testing.T) {
+	for _, name := range []string{"", "two words", "3", "help", "Back", "EXIT", "dup", "DUP"} {
+		t.Run(name, func(t *testing.T) {
+			m := New("")
+			if name == "dup" || name == "DUP" {
+				m.Add("dup", "", nil)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Add(%q) did not panic", name)
+				}
+			}()
+			m.Add(name, "", nil)
+		})
+	}
+}
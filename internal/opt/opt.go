@@ -0,0 +1,172 @@
+// Package opt gives optional values one type, Optional, in place of the
+// pointers chapter's nil-pointer pattern, where processData took a
+// *time.Duration and nil meant "use the default".
+//
+// A pointer can say "not set", but it also says "shared" and "may be
+// changed by someone else", and reading it before checking for nil
+// panics. An Optional is a plain value holding a T and whether it is set:
+//
+//	timeout := opt.None[time.Duration]()
+//	fmt.Println(timeout.OrElse(30 * time.Second)) // 30s
+//
+// In JSON an unset Optional is null, and with the omitzero option it is
+// left out altogether. In a database it is NULL, as with sql.Null, which
+// FromNull and Null convert to and from. Ptr and Deref help with APIs that
+// still use pointers.
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Optional holds either a value of type T or nothing. The zero value holds
+// nothing.
+type Optional[T any] struct {
+	v  T
+	ok bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] { return Optional[T]{v, true} }
+
+// None returns an Optional holding nothing.
+func None[T any]() Optional[T] { return Optional[T]{} }
+
+// FromPtr returns an Optional holding *p, or nothing if p is nil.
+func FromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// FromNull returns an Optional holding n.V if n is valid.
+func FromNull[T any](n sql.Null[T]) Optional[T] { return Optional[T]{n.V, n.Valid} }
+
+// IsSome reports whether o holds a value.
+func (o Optional[T]) IsSome() bool { return o.ok }
+
+// IsZero reports whether o holds nothing. It lets encoding/json leave out
+// a field tagged omitzero when it is unset.
+func (o Optional[T]) IsZero() bool { return !o.ok }
+
+// Get returns the value o holds and true, or the zero T and false.
+func (o Optional[T]) Get() (T, bool) { return o.v, o.ok }
+
+// OrElse returns the value o holds, or def if it holds nothing.
+func (o Optional[T]) OrElse(def T) T {
+	if o.ok {
+		return o.v
+	}
+	return def
+}
+
+// OrElseFunc returns the value o holds, or else the result of calling def,
+// for defaults that are costly to work out.
+func (o Optional[T]) OrElseFunc(def func() T) T {
+	if o.ok {
+		return o.v
+	}
+	return def()
+}
+
+// MustGet returns the value o holds, and panics if it holds nothing.
+func (o Optional[T]) MustGet() T {
+	if !o.ok {
+		panic("opt: MustGet on an empty Optional")
+	}
+	return o.v
+}
+
+// Ptr returns a pointer to a copy of the value o holds, or nil.
+func (o Optional[T]) Ptr() *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.v
+	return &v
+}
+
+// Null returns o as an sql.Null.
+func (o Optional[T]) Null() sql.Null[T] { return sql.Null[T]{V: o.v, Valid: o.ok} }
+
+// String formats o as "Some(v)" or "None".
+func (o Optional[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.v)
+}
+
+// Map returns an Optional holding f of the value o holds, or nothing if o
+// holds nothing; f is then not called. It is a function rather than a
+// method because methods cannot have type parameters of their own.
+func Map[T, U any](o Optional[T], f func(T) U) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.v))
+}
+
+// FlatMap is like Map for an f that may itself produce nothing.
+func FlatMap[T, U any](o Optional[T], f func(T) Optional[U]) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return f(o.v)
+}
+
+// MarshalJSON writes the value o holds, or null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.v)
+}
+
+// UnmarshalJSON reads null as nothing, and anything else as a T. A field
+// missing from the JSON is left as it was, which for a new struct is
+// nothing too.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// Scan reads a database column, as sql.Scanner requires. NULL is read as
+// nothing, and anything else is converted to T as sql.Null converts it.
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return fmt.Errorf("opt: %w", err)
+	}
+	*o = FromNull(n)
+	return nil
+}
+
+// Value stores o in a database, as NULL if it holds nothing, as
+// driver.Valuer requires.
+func (o Optional[T]) Value() (driver.Value, error) { return o.Null().Value() }
+
+// Ptr returns a pointer to a copy of v. It is for filling pointer fields
+// from constants and other values that cannot have their address taken:
+// opt.Ptr(60 * time.Second).
+func Ptr[T any](v T) *T { return &v }
+
+// Deref returns *p, or def if p is nil.
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
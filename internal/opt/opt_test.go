@@ -0,0 +1,145 @@
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOptional(t *testing.T) {
+	some, none := Some(0), None[int]()
+	if !some.IsSome() || none.IsSome() || some.IsZero() || !none.IsZero() {
+		t.Error("IsSome or IsZero wrong")
+	}
+	if some.OrElse(7) != 0 || none.OrElse(7) != 7 {
+		t.Error("OrElse did not tell a set zero from nothing")
+	}
+	called := false
+	if none.OrElseFunc(func() int { called = true; return 8 }) != 8 || !called {
+		t.Error("OrElseFunc did not call def")
+	}
+	if v, ok := none.Get(); v != 0 || ok {
+		t.Errorf("None Get() = %d, %t", v, ok)
+	}
+	if some.String() != "Some(0)" || none.String() != "None" {
+		t.Errorf("String() = %q, %q", some, none)
+	}
+	if none != (Optional[int]{}) {
+		t.Error("None is not the zero value")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustGet on None did not panic")
+		}
+	}()
+	none.MustGet()
+}
+
+func TestMap(t *testing.T) {
+	if got := Map(Some(21), strconv.Itoa); got != Some("21") {
+		t.Errorf("Map(Some) = %v", got)
+	}
+	if got := Map(None[int](), func(int) string { panic("called") }); got.IsSome() {
+		t.Errorf("Map(None) = %v", got)
+	}
+	parse := func(s string) Optional[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(n)
+	}
+	if got := FlatMap(Some("12"), parse); got != Some(12) {
+		t.Errorf("FlatMap(12) = %v", got)
+	}
+	if got := FlatMap(Some("x"), parse); got.IsSome() {
+		t.Errorf("FlatMap(x) = %v", got)
+	}
+}
+
+func TestPointers(t *testing.T) {
+	p := Ptr(60 * time.Second)
+	if Deref(p, time.Second) != time.Minute || Deref(nil, time.Second) != time.Second {
+		t.Error("Deref wrong")
+	}
+	o := FromPtr(p)
+	*p = 0
+	if o != Some(time.Minute) {
+		t.Errorf("FromPtr shares with the pointer: %v", o)
+	}
+	if FromPtr[int](nil).IsSome() || None[int]().Ptr() != nil {
+		t.Error("nil did not map to None and back")
+	}
+	q := o.Ptr()
+	*q = 0
+	if o.MustGet() != time.Minute {
+		t.Error("Ptr shares with the Optional")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type settings struct {
+		Name    string           `json:"name"`
+		Retries Optional[int]    `json:"retries,omitzero"`
+		Note    Optional[string] `json:"note"`
+	}
+	tests := []struct {
+		in   string
+		want settings
+		out  string
+	}{
+		{`{"name":"a"}`, settings{Name: "a"}, `{"name":"a","note":null}`},
+		{`{"name":"a","retries":0,"note":"hi"}`, settings{"a", Some(0), Some("hi")}, `{"name":"a","retries":0,"note":"hi"}`},
+		{`{"name":"a","retries":null,"note":null}`, settings{Name: "a"}, `{"name":"a","note":null}`},
+	}
+	for _, tt := range tests {
+		var got settings
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+		if out, err := json.Marshal(got); err != nil || string(out) != tt.out {
+			t.Errorf("Marshal(%+v) = %s, %v; want %s", got, out, err, tt.out)
+		}
+	}
+
+	var o Optional[int]
+	if err := json.Unmarshal([]byte(`"3"`), &o); err == nil {
+		t.Errorf("a string unmarshalled into Optional[int]: %v", o)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var n Optional[int64]
+	if err := n.Scan(int64(5)); err != nil || n != Some[int64](5) {
+		t.Errorf("Scan(5) = %v, %v", n, err)
+	}
+	if err := n.Scan(nil); err != nil || n.IsSome() {
+		t.Errorf("Scan(nil) = %v, %v", n, err)
+	}
+	var s Optional[string]
+	if err := s.Scan([]byte("hi")); err != nil || s != Some("hi") {
+		t.Errorf("Scan(bytes) = %v, %v", s, err)
+	}
+	var ts Optional[time.Time]
+	if err := ts.Scan("not a time"); err == nil {
+		t.Errorf("Scan(string) into a time = %v", ts)
+	}
+
+	if v, err := Some(int64(5)).Value(); err != nil || v != driver.Value(int64(5)) {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if v, err := None[string]().Value(); err != nil || v != nil {
+		t.Errorf("None Value() = %v, %v", v, err)
+	}
+
+	null := sql.Null[string]{V: "x", Valid: true}
+	if FromNull(null) != Some("x") || Some("x").Null() != null {
+		t.Error("sql.Null conversion wrong")
+	}
+	var _ sql.Scanner = (*Optional[int])(nil)
+	var _ driver.Valuer = Optional[int]{}
+}
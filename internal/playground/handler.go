@@ -0,0 +1,126 @@
+package playground
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/sumit-covlant/go_tutorial/internal/catalog"
+)
+
+const maxRequest = 64 << 10
+
+// ServeHTTP runs the Request in a POSTed JSON body and streams its events
+// as server-sent events: each has the event name of the Event and its data,
+// split into one data field per line as the protocol requires. A failure
+// to start the run is sent as an "error" event.
+//
+// Running code is for the reader's own browser only. Requests must be
+// addressed to localhost, come from the same origin, and have the
+// Content-Type application/json, which a page elsewhere cannot send
+// without the browser asking first; so neither another web site nor one
+// that resolves its name to 127.0.0.1 can have a program run.
+func (r *Runner) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isLoopback(req.Host) {
+		http.Error(w, "programs can only be run from localhost", http.StatusForbidden)
+		return
+	}
+	if err := crossOrigin.Check(req); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mt != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	var run Request
+	dec := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequest))
+	if err := dec.Decode(&run); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := r.check(run); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// The limits bound the run; the server's write timeout must not.
+	rc.SetWriteDeadline(time.Now().Add(r.BuildTimeout + r.RunTimeout + 10*time.Second))
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(e Event) {
+		writeEvent(w, e)
+		rc.Flush()
+	}
+	if err := r.Run(req.Context(), run, send); err != nil && req.Context().Err() == nil {
+		send(Event{"error", err.Error()})
+	}
+}
+
+var crossOrigin = http.NewCrossOriginProtection()
+
+// isLoopback reports whether host, from a request's Host header, names
+// this machine's loopback interface.
+func isLoopback(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if host == "localhost" {
+		return true
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.IsLoopback()
+}
+
+// check rejects requests that name no program, or an example that does
+// not exist.
+func (r *Runner) check(run Request) error {
+	switch {
+	case run.Code != "" && run.Chapter != "":
+		return errors.New("send code or an example, not both")
+	case run.Code != "":
+		return nil
+	case run.Chapter == "" || run.Section == "":
+		return errors.New("missing code, or chapter and section")
+	case r.Examples == "":
+		return errors.New("running chapter examples is not enabled")
+	}
+	c, ok := catalog.Lookup(run.Chapter)
+	if !ok {
+		return fmt.Errorf("unknown chapter %q", run.Chapter)
+	}
+	if _, ok := c.Section(run.Section); !ok {
+		return fmt.Errorf("chapter %d has no section %q", c.Number, run.Section)
+	}
+	return nil
+}
+
+func writeEvent(w io.Writer, e Event) {
+	var b strings.Builder
+	b.WriteString("event: " + e.Name + "\n")
+	// A lone carriage return also ends a line in the protocol.
+	data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(e.Data)
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	io.WriteString(w, b.String())
+}
@@ -0,0 +1,243 @@
+// Package playground compiles and runs Go programs sent from the tutorial's
+// web pages and streams their output back as server-sent events.
+//
+// A program is written to a temporary module and built with the local
+// toolchain, a minimal environment and no module proxy, so it can only
+// use the standard library. The binary then runs with a CPU and
+// wall-clock limit, a cap on how much it may print, and (on Linux, the
+// only platform where it runs at all) in a sandbox: namespaces of its own
+// with no network but loopback, a read-only root holding little but the
+// program, a small tmpfs as its work directory, and limits on memory,
+// processes and file size. Chapter examples run the same way, through the
+// gotutor binary.
+package playground
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Event is one piece of a run's progress.
+type Event struct {
+	// Name is "stdout" or "stderr" for output, "build" for compiler
+	// errors, and "done" for the final status, such as "exit status 0"
+	// or "time limit exceeded".
+	Name string
+	Data string
+}
+
+// Request says what to run: a complete program, or a chapter example.
+type Request struct {
+	Code    string `json:"code,omitempty"`
+	Chapter string `json:"chapter,omitempty"` // number or slug
+	Section string `json:"section,omitempty"` // example function name
+}
+
+// Runner runs requests. Its fields may be changed before first use.
+type Runner struct {
+	Go           string        // go command used to build programs
+	Examples     string        // gotutor binary used for chapter examples; "" disables them
+	BuildTimeout time.Duration // for compiling a program
+	RunTimeout   time.Duration // wall-clock and CPU time for running it
+	MaxOutput    int           // bytes of stdout and stderr together
+	MaxMemory    int64         // bytes of address space
+	MaxProcs     int           // processes and threads at once
+	MaxDisk      int64         // bytes in the work directory, and per file
+	GoCache      string        // GOCACHE for builds
+
+	slots chan struct{} // limits how many runs happen at once
+}
+
+// New returns a Runner with the default limits that allows two runs at a
+// time.
+func New() *Runner {
+	return &Runner{
+		Go:           "go",
+		BuildTimeout: 30 * time.Second,
+		RunTimeout:   10 * time.Second,
+		MaxOutput:    64 << 10,
+		MaxMemory:    1 << 30,
+		MaxProcs:     64,
+		MaxDisk:      16 << 20,
+		GoCache:      goCache(),
+		slots:        make(chan struct{}, 2),
+	}
+}
+
+// goCache returns the build cache the go command would use, so that
+// programs build against the already compiled standard library.
+func goCache() string {
+	if dir := os.Getenv("GOCACHE"); dir != "" {
+		return dir
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "go-build")
+	}
+	return filepath.Join(os.TempDir(), "playground-go-build")
+}
+
+// errBusy is returned when every slot is taken.
+var errBusy = errors.New("too many programs running; try again shortly")
+
+// Run carries out req, calling emit for each event. It returns an error
+// only if the run could not be attempted, or if ctx was cancelled; the
+// program failing is reported through a "done" event instead.
+func (r *Runner) Run(ctx context.Context, req Request, emit func(Event)) error {
+	select {
+	case r.slots <- struct{}{}:
+		defer func() { <-r.slots }()
+	default:
+		return errBusy
+	}
+
+	dir, err := os.MkdirTemp("", "playground-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	switch {
+	case req.Code != "":
+		prog, ok, err := r.build(ctx, dir, req.Code, emit)
+		if err != nil || !ok {
+			return err
+		}
+		return r.run(ctx, dir, emit, prog)
+	case r.Examples != "":
+		return r.run(ctx, dir, emit, r.Examples, "run", req.Chapter, req.Section)
+	default:
+		return errors.New("running chapter examples is not enabled")
+	}
+}
+
+const goMod = "module playground\n\ngo 1.25\n"
+
+// build compiles code in dir. A program that does not compile is reported
+// as a "build" and a "done" event, with ok false and a nil error.
+func (r *Runner) build(ctx context.Context, dir, code string, emit func(Event)) (prog string, ok bool, err error) {
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		return "", false, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0644); err != nil {
+		return "", false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.BuildTimeout)
+	defer cancel()
+	prog = filepath.Join(dir, "prog")
+	cmd := exec.CommandContext(ctx, r.Go, "build", "-o", prog, ".")
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"HOME=" + dir,
+		"GOPATH=" + filepath.Join(dir, "gopath"),
+		"GOCACHE=" + r.GoCache,
+		"GOPROXY=off",
+		"GOFLAGS=-mod=mod",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+		"CGO_ENABLED=0",
+	}
+	out, err := cmd.CombinedOutput()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		emit(Event{"done", "build time limit exceeded"})
+		return "", false, nil
+	case ctx.Err() != nil:
+		return "", false, ctx.Err()
+	case err != nil:
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return "", false, err
+		}
+		emit(Event{"build", string(out)})
+		emit(Event{"done", "build failed"})
+		return "", false, nil
+	}
+	return prog, true, nil
+}
+
+// run runs name in the sandbox, from dir, and streams its output.
+func (r *Runner) run(ctx context.Context, dir string, emit func(Event), name string, args ...string) error {
+	runCtx, cancel := context.WithTimeout(ctx, r.RunTimeout)
+	defer cancel()
+
+	out := &output{emit: emit, left: r.MaxOutput, cancel: cancel}
+	cmd := exec.CommandContext(runCtx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = []string{"HOME=/tmp", "TMPDIR=/tmp", "GOMAXPROCS=1"} // as the sandbox sees it
+	cmd.Stdout = out.stream("stdout")
+	cmd.Stderr = out.stream("stderr")
+	cmd.WaitDelay = time.Second
+	if err := r.sandbox(cmd); err != nil {
+		return err
+	}
+
+	err := cmd.Run()
+	var status string
+	switch {
+	case out.exceeded():
+		status = "output limit exceeded"
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case runCtx.Err() == context.DeadlineExceeded:
+		status = "time limit exceeded"
+	case err == nil:
+		status = cmd.ProcessState.String()
+	default:
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+		status = exitErr.ProcessState.String()
+	}
+	emit(Event{"done", status})
+	return nil
+}
+
+// output forwards what a program writes as events until its budget runs
+// out, then stops the program.
+type output struct {
+	mu     sync.Mutex
+	emit   func(Event)
+	left   int
+	over   bool
+	cancel func()
+}
+
+func (o *output) stream(name string) *stream { return &stream{o, name} }
+
+func (o *output) exceeded() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.over
+}
+
+type stream struct {
+	out  *output
+	name string
+}
+
+func (s *stream) Write(p []byte) (int, error) {
+	o := s.out
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.over {
+		return len(p), nil // discard, but keep the pipe drained
+	}
+	data := p
+	if len(data) > o.left {
+		data = data[:o.left]
+		o.over = true
+		o.cancel()
+	}
+	o.left -= len(data)
+	if len(data) > 0 {
+		o.emit(Event{s.name, string(data)})
+	}
+	return len(p), nil
+}
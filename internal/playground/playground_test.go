@@ -0,0 +1,222 @@
+package playground
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("programs only run on Linux")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("no go command")
+	}
+	r := New()
+	r.RunTimeout = 2 * time.Second
+	r.MaxOutput = 1000
+	return r
+}
+
+// run runs req and returns the concatenated stdout and stderr, the build
+// output and the final status.
+func run(t *testing.T, r *Runner, req Request) (output, build, status string) {
+	t.Helper()
+	var out, b strings.Builder
+	err := r.Run(context.Background(), req, func(e Event) {
+		switch e.Name {
+		case "stdout", "stderr":
+			out.WriteString(e.Data)
+		case "build":
+			b.WriteString(e.Data)
+		case "done":
+			status = e.Data
+		default:
+			t.Errorf("unexpected event %v", e)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.String(), b.String(), status
+}
+
+func program(body string) string {
+	return "package main\n\nimport (\n\t\"fmt\"\n\t\"net\"\n\t\"os\"\n\t\"syscall\"\n\t\"time\"\n)\n\n" +
+		"var _, _, _, _, _ = fmt.Print, net.Dial, os.Exit, syscall.Getpid, time.Sleep\n\nfunc main() {\n" + body + "\n}\n"
+}
+
+func TestRun(t *testing.T) {
+	r := newTestRunner(t)
+	out, _, status := run(t, r, Request{Code: program(`fmt.Println("hello"); fmt.Fprintln(os.Stderr, "oops"); os.Exit(3)`)})
+	if out != "hello\noops\n" || status != "exit status 3" {
+		t.Errorf("got output %q, status %q", out, status)
+	}
+}
+
+func TestBuildError(t *testing.T) {
+	r := newTestRunner(t)
+	_, build, status := run(t, r, Request{Code: program(`undefined()`)})
+	if !strings.Contains(build, "undefined: undefined") || status != "build failed" {
+		t.Errorf("got build output %q, status %q", build, status)
+	}
+}
+
+func TestNoNetwork(t *testing.T) {
+	r := newTestRunner(t)
+	out, _, _ := run(t, r, Request{Code: program(`
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, i := range ifaces {
+		fmt.Println(i.Name, i.Flags&net.FlagUp != 0)
+	}
+	_, err = net.Dial("tcp", "1.1.1.1:80")
+	fmt.Println(err != nil)`)})
+	if out != "lo false\ntrue\n" {
+		t.Errorf("the program saw the network:\n%s", out)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	r := newTestRunner(t)
+	for _, tt := range []struct {
+		body, status string
+	}{
+		{`for {}`, "time limit exceeded"},
+		{`time.Sleep(time.Hour)`, "time limit exceeded"},
+		{`for { fmt.Println("spam") }`, "output limit exceeded"},
+	} {
+		start := time.Now()
+		out, _, status := run(t, r, Request{Code: program(tt.body)})
+		if status != tt.status {
+			t.Errorf("%s: status %q, want %q", tt.body, status, tt.status)
+		}
+		if len(out) > r.MaxOutput {
+			t.Errorf("%s: %d bytes of output, more than the limit", tt.body, len(out))
+		}
+		if d := time.Since(start); d > r.BuildTimeout+r.RunTimeout {
+			t.Errorf("%s: took %v", tt.body, d)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := newTestRunner(t)
+	r.Examples = "/bin/echo" // prints the arguments it would pass to gotutor
+
+	request := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "http://localhost:3000/run", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+	post := func(body string) *httptest.ResponseRecorder { return serve(request(body)) }
+
+	rec := post(`{"chapter": "12", "section": "waitGroupExample"}`)
+	want := "event: stdout\ndata: run 12 waitGroupExample\ndata: \n\nevent: done\ndata: exit status 0\n\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("got %d:\n%s\nwant:\n%s", rec.Code, rec.Body, want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	for _, body := range []string{
+		`{}`,
+		`{"chapter": "12", "section": "noSuchExample"}`,
+		`{"chapter": "99", "section": "main"}`,
+		`{"code": "package main", "chapter": "12"}`,
+		`not json`,
+	} {
+		if rec := post(body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", body, rec.Code)
+		}
+	}
+
+	// What a page on another site could send.
+	valid := `{"chapter": "12", "section": "waitGroupExample"}`
+	for _, tt := range []struct {
+		name   string
+		change func(*http.Request)
+		code   int
+	}{
+		{"form", func(req *http.Request) { req.Header.Set("Content-Type", "text/plain") }, http.StatusUnsupportedMediaType},
+		{"no type", func(req *http.Request) { req.Header.Del("Content-Type") }, http.StatusUnsupportedMediaType},
+		{"other origin", func(req *http.Request) { req.Header.Set("Origin", "https://example.com") }, http.StatusForbidden},
+		{"cross-site fetch", func(req *http.Request) { req.Header.Set("Sec-Fetch-Site", "cross-site") }, http.StatusForbidden},
+		{"rebound name", func(req *http.Request) { req.Host = "attacker.example:3000" }, http.StatusForbidden},
+	} {
+		req := request(valid)
+		tt.change(req)
+		if rec := serve(req); rec.Code != tt.code {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.code)
+		}
+	}
+	for _, host := range []string{"127.0.0.1:3000", "[::1]:3000", "localhost"} {
+		req := request(valid)
+		req.Host = host
+		req.Header.Set("Origin", "http://"+host)
+		if rec := serve(req); rec.Code != http.StatusOK {
+			t.Errorf("Host %s: status %d, want 200", host, rec.Code)
+		}
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	r := newTestRunner(t)
+	host, err := filepath.Abs("playground_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _, status := run(t, r, Request{Code: program(fmt.Sprintf(`
+	_, err := os.ReadFile(%q)
+	fmt.Println("host file:", err != nil)
+	fmt.Println("uid:", os.Getuid(), "pid:", os.Getpid())
+	fmt.Println("write /:", os.WriteFile("/x", nil, 0644) != nil)
+	fmt.Println("write /tmp:", os.WriteFile("/tmp/x", []byte("x"), 0644) == nil)
+	wd, _ := os.Getwd()
+	fmt.Println("wd:", wd)
+	_, err = syscall.Mmap(-1, 0, 2<<30, syscall.PROT_READ, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	fmt.Println("2 GiB:", err)`, host))})
+	want := "host file: true\nuid: 65534 pid: 1\nwrite /: true\nwrite /tmp: true\nwd: /tmp\n2 GiB: cannot allocate memory\n"
+	if out != want || status != "exit status 0" {
+		t.Errorf("got output %q, status %q\nwant %q", out, status, want)
+	}
+}
+
+// TestEscape checks that a process which leaves its process group still
+// dies with the program.
+func TestEscape(t *testing.T) {
+	r := newTestRunner(t)
+	start := time.Now()
+	_, _, status := run(t, r, Request{Code: program(`
+	if os.Getenv("CHILD") != "" {
+		time.Sleep(time.Hour)
+	}
+	_, err := os.StartProcess("/prog", nil, &os.ProcAttr{
+		Env:   []string{"CHILD=1"},
+		Files: []*os.File{nil, os.Stdout, os.Stderr},
+		Sys:   &syscall.SysProcAttr{Setsid: true},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}`)})
+	if status != "exit status 0" || time.Since(start) > r.RunTimeout {
+		t.Errorf("status %q after %v", status, time.Since(start))
+	}
+}
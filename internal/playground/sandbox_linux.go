@@ -0,0 +1,289 @@
+package playground
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// A program runs in a sandbox set up by a helper: this same binary,
+// started with initArg as its name in new user, mount, PID, network, IPC
+// and UTS namespaces. The helper is PID 1 of its PID namespace, so
+// killing it kills every process the program started, however they
+// detach. It builds a root directory holding only the program, the
+// system libraries a dynamically linked program needs, a few devices and
+// a tmpfs work directory at /tmp; pivots into it and makes it read-only;
+// sets the resource limits; drops its capabilities; and finally execs the
+// program in its place.
+//
+// Inside, the program runs as nobody (uid 65534), mapped to the uid of
+// the server. The kernel counts RLIMIT_NPROC per user namespace, but does
+// not enforce it at all when the server runs as root.
+const initArg = "playground-sandbox"
+
+const nobody = 65534
+
+func init() {
+	if len(os.Args) > 0 && os.Args[0] == initArg {
+		if err := sandboxInit(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "playground: sandbox: %v\n", err)
+		}
+		os.Exit(127)
+	}
+}
+
+// sandbox rewrites cmd to run its program through the helper.
+func (r *Runner) sandbox(cmd *exec.Cmd) error {
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	limits := []string{
+		strconv.FormatInt(int64((r.RunTimeout+time.Second-1)/time.Second), 10),
+		strconv.FormatInt(r.MaxMemory, 10),
+		strconv.Itoa(r.MaxProcs),
+		strconv.FormatInt(r.MaxDisk, 10),
+	}
+	cmd.Args = append(append([]string{initArg}, limits...), append([]string{cmd.Path}, cmd.Args[1:]...)...)
+	cmd.Path = self
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID |
+			syscall.CLONE_NEWNET | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: nobody, HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: nobody, HostID: os.Getgid(), Size: 1}},
+		// Enough to mount and pivot_root; the helper drops it before
+		// running the program.
+		AmbientCaps: []uintptr{capSysAdmin},
+	}
+	return nil
+}
+
+const (
+	capSysAdmin = 21
+
+	rlimitNproc = 6
+
+	prSetNoNewPrivs   = 38
+	prCapAmbient      = 47
+	prCapAmbientClear = 4
+)
+
+// sandboxInit runs in the helper, with args as sandbox wrote them, and
+// only returns if it fails.
+func sandboxInit(args []string) error {
+	if len(args) < 5 {
+		return errors.New("missing arguments")
+	}
+	var limits [4]uint64
+	for i := range limits {
+		n, err := strconv.ParseUint(args[i], 10, 64)
+		if err != nil {
+			return err
+		}
+		limits[i] = n
+	}
+	cpu, memory, procs, disk := limits[0], limits[1], limits[2], limits[3]
+	prog, progArgs := args[4], args[4:]
+
+	// Capabilities, and the no_new_privs bit, belong to a thread; keep
+	// this goroutine on the one that will exec.
+	runtime.LockOSThread()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(dir, "root")
+	if err := buildRoot(root, prog, disk); err != nil {
+		return err
+	}
+	if err := pivot(root); err != nil {
+		return err
+	}
+
+	for _, l := range []struct {
+		resource int
+		value    uint64
+	}{
+		{syscall.RLIMIT_CPU, cpu},
+		{syscall.RLIMIT_AS, memory},
+		{rlimitNproc, procs},
+		{syscall.RLIMIT_FSIZE, disk},
+		{syscall.RLIMIT_NOFILE, 256},
+		{syscall.RLIMIT_CORE, 0},
+	} {
+		lim := syscall.Rlimit{Cur: l.value, Max: l.value}
+		if l.resource == syscall.RLIMIT_CPU {
+			lim.Max++ // SIGXCPU at the limit, SIGKILL a second later
+		}
+		if err := syscall.Setrlimit(l.resource, &lim); err != nil {
+			return fmt.Errorf("rlimit %d: %w", l.resource, err)
+		}
+	}
+
+	if err := prctl(prSetNoNewPrivs, 1, 0); err != nil {
+		return fmt.Errorf("no_new_privs: %w", err)
+	}
+	if err := prctl(prCapAmbient, prCapAmbientClear, 0); err != nil {
+		return fmt.Errorf("dropping capabilities: %w", err)
+	}
+	return syscall.Exec("/prog", progArgs, os.Environ())
+}
+
+// buildRoot mounts a tmpfs at root and fills it with what the program
+// needs.
+func buildRoot(root, prog string, disk uint64) error {
+	// Keep the mounts below from propagating back to the host.
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making / private: %w", err)
+	}
+	if err := os.Mkdir(root, 0755); err != nil {
+		return err
+	}
+	if err := syscall.Mount("tmpfs", root, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "size=1m,mode=0755"); err != nil {
+		return fmt.Errorf("mounting root: %w", err)
+	}
+
+	binds := []string{"/dev/null", "/dev/zero", "/dev/urandom"}
+	if dynamic(prog) {
+		binds = append(binds, "/lib", "/lib64", "/usr/lib", "/usr/lib64")
+	}
+	for _, src := range binds {
+		if err := bindReadOnly(src, filepath.Join(root, src)); err != nil {
+			return err
+		}
+	}
+	if err := bindReadOnly(prog, filepath.Join(root, "prog")); err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(root, "tmp")
+	if err := os.Mkdir(tmp, 0700); err != nil {
+		return err
+	}
+	opts := fmt.Sprintf("size=%d,mode=0700", disk)
+	if err := syscall.Mount("tmpfs", tmp, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, opts); err != nil {
+		return fmt.Errorf("mounting /tmp: %w", err)
+	}
+	return os.Mkdir(filepath.Join(root, ".old"), 0700)
+}
+
+// pivot makes root the root directory, leaves nothing of the old one
+// reachable, makes the new one read-only and moves into /tmp.
+func pivot(root string) error {
+	if err := syscall.PivotRoot(root, filepath.Join(root, ".old")); err != nil {
+		return fmt.Errorf("pivot_root: %w", err)
+	}
+	if err := syscall.Chdir("/"); err != nil {
+		return err
+	}
+	if err := syscall.Unmount("/.old", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmounting the old root: %w", err)
+	}
+	if err := os.Remove("/.old"); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_REMOUNT | syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NODEV)
+	if err := syscall.Mount("", "/", "", flags, ""); err != nil {
+		return fmt.Errorf("making / read-only: %w", err)
+	}
+	return syscall.Chdir("/tmp")
+}
+
+// bindReadOnly bind-mounts src at dst, read-only, creating dst to mount on
+// and copying a symbolic link rather than following it. It does nothing
+// if src does not exist.
+func bindReadOnly(src, dst string) error {
+	fi, err := os.Lstat(src)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+	case fi.IsDir():
+		err = os.Mkdir(dst, 0755)
+	default:
+		err = os.WriteFile(dst, nil, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	if err := syscall.Mount(src, dst, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("binding %s: %w", src, err)
+	}
+	// A mount made in a user namespace must keep the flags that the
+	// original had, or the remount fails.
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(src, &st); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_REMOUNT | syscall.MS_BIND | syscall.MS_RDONLY | syscall.MS_NOSUID)
+	for bit, ms := range map[int64]uintptr{
+		stNodev:      syscall.MS_NODEV,
+		stNoexec:     syscall.MS_NOEXEC,
+		stNoatime:    syscall.MS_NOATIME,
+		stNodiratime: syscall.MS_NODIRATIME,
+		stRelatime:   syscall.MS_RELATIME,
+	} {
+		if st.Flags&bit != 0 {
+			flags |= ms
+		}
+	}
+	if err := syscall.Mount("", dst, "", flags, ""); err != nil {
+		return fmt.Errorf("making %s read-only: %w", src, err)
+	}
+	return nil
+}
+
+// Flags in Statfs_t.Flags.
+const (
+	stNodev      = 4
+	stNoexec     = 8
+	stNoatime    = 1024
+	stNodiratime = 2048
+	stRelatime   = 4096
+)
+
+// dynamic reports whether the executable at path needs the dynamic linker,
+// and so the system libraries.
+func dynamic(path string) bool {
+	f, err := elf.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_INTERP {
+			return true
+		}
+	}
+	return false
+}
+
+func prctl(option, arg2, arg3 uintptr) error {
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, option, arg2, arg3); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package playground
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// Elsewhere there is no unprivileged way to keep a program off the
+// network and the file system, so programs are not run at all.
+func (r *Runner) sandbox(*exec.Cmd) error {
+	return errors.New("running programs is only supported on Linux")
+}
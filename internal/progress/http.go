@@ -0,0 +1,123 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const maxRequest = 4 << 10
+
+// Handler serves a learner's progress through a chapter to the docs site.
+//
+// GET ?learner=name&chapter=N answers with a JSON object holding the IDs
+// of the sections the learner has read and their exercise results. POST
+// with a JSON body {"learner", "chapter", "section", "spent"} records that
+// a section was read, after spent seconds on the page.
+type Handler struct {
+	Store *Store
+	// Sections holds the section IDs of each chapter; only these can be
+	// marked as read.
+	Sections map[int][]string
+}
+
+// chapterProgress is the GET response.
+type chapterProgress struct {
+	Learner   string             `json:"learner"`
+	Chapter   int                `json:"chapter"`
+	Sections  []string           `json:"sections"`
+	Exercises []exerciseProgress `json:"exercises"`
+}
+
+type exerciseProgress struct {
+	Name     string `json:"name"`
+	Attempts int    `json:"attempts"`
+	Passed   bool   `json:"passed"`
+}
+
+// sectionRead is the POST body.
+type sectionRead struct {
+	Learner string  `json:"learner"`
+	Chapter int     `json:"chapter"`
+	Section string  `json:"section"`
+	Spent   float64 `json:"spent"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.get(w, r)
+	case http.MethodPost:
+		h.post(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "use GET or POST", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	learner := q.Get("learner")
+	if err := CheckLearner(learner); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chapter, err := strconv.Atoi(q.Get("chapter"))
+	if err != nil {
+		http.Error(w, "chapter must be a number", http.StatusBadRequest)
+		return
+	}
+	records, err := h.Store.Records()
+	if err != nil {
+		http.Error(w, "reading progress: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := chapterProgress{Learner: learner, Chapter: chapter, Sections: []string{}, Exercises: []exerciseProgress{}}
+	for _, p := range Summarize(records) {
+		if p.Learner != learner || p.Chapter != chapter {
+			continue
+		}
+		resp.Sections = append(resp.Sections, p.Sections...)
+		for _, e := range p.Exercises {
+			resp.Exercises = append(resp.Exercises, exerciseProgress{e.Name, e.Attempts, e.Passed})
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) post(w http.ResponseWriter, r *http.Request) {
+	var read sectionRead
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequest))
+	if err := dec.Decode(&read); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := CheckLearner(read.Learner); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !slices.Contains(h.Sections[read.Chapter], read.Section) {
+		http.Error(w, fmt.Sprintf("chapter %d has no section %q", read.Chapter, read.Section), http.StatusBadRequest)
+		return
+	}
+	// The page measures the time; trust it only up to MaxSpent.
+	spent := time.Duration(min(max(read.Spent, 0), MaxSpent.Seconds()) * float64(time.Second))
+	err := h.Store.Add(Record{
+		Learner: read.Learner,
+		Chapter: read.Chapter,
+		Kind:    Section,
+		Name:    read.Section,
+		Spent:   Seconds(spent),
+	})
+	if err != nil {
+		http.Error(w, "recording progress: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+// Package progress records how far each learner has got through the
+// tutorial: the chapter sections they have read on the docs site and the
+// exercises they have checked with gotutor check.
+//
+// A Store is a single file of JSON lines that is only ever appended to.
+// Each line is a Record, written with one write call on a file opened with
+// O_APPEND, so gotutor check and gotutor serve can share a store, and a
+// crash costs at most the line being written.
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Kind says what a Record is about.
+type Kind string
+
+const (
+	Section  Kind = "section"  // the learner read a section of a chapter
+	Exercise Kind = "exercise" // the learner checked an exercise
+)
+
+// Record is one line of a store.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Learner string    `json:"learner"`
+	Chapter int       `json:"chapter"`
+	Kind    Kind      `json:"kind"`
+	Name    string    `json:"name"`             // section ID or exercise name
+	Passed  bool      `json:"passed,omitempty"` // exercises only
+	// Spent is the time spent reading a section, as measured by the page,
+	// or the time an exercise's grader took. Summarize adds the time
+	// between attempts to the latter.
+	Spent Seconds `json:"spent,omitempty"`
+}
+
+// Seconds is a duration that is stored as a number of seconds, to the
+// millisecond.
+type Seconds time.Duration
+
+func (s Seconds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(s).Round(time.Millisecond).Seconds())
+}
+
+func (s *Seconds) UnmarshalJSON(data []byte) error {
+	var n float64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*s = Seconds(time.Duration(n * float64(time.Second)).Round(time.Millisecond))
+	return nil
+}
+
+// MaxSpent caps the reading time a single Record may claim, so that a page
+// left open overnight does not count as a night of study.
+const MaxSpent = time.Hour
+
+// CheckLearner reports whether name can be used as a learner's name: it
+// must be 1 to 64 characters with no control characters, and must not
+// start or end with a space.
+func CheckLearner(name string) error {
+	switch {
+	case name == "":
+		return errors.New("no learner name")
+	case len([]rune(name)) > 64:
+		return errors.New("learner name is longer than 64 characters")
+	case strings.TrimSpace(name) != name:
+		return errors.New("learner name starts or ends with a space")
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return errors.New("learner name has a control character")
+	}
+	return nil
+}
+
+func (r *Record) check() error {
+	if err := CheckLearner(r.Learner); err != nil {
+		return err
+	}
+	if r.Kind != Section && r.Kind != Exercise {
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+	if r.Name == "" {
+		return fmt.Errorf("%s has no name", r.Kind)
+	}
+	if r.Spent < 0 || time.Duration(r.Spent) > MaxSpent {
+		return fmt.Errorf("time spent %v is not between 0 and %v", time.Duration(r.Spent), MaxSpent)
+	}
+	return nil
+}
+
+// Store is an append-only file of Records. It is safe for concurrent use.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+	now  func() time.Time
+}
+
+// DefaultPath returns $GOTUTOR_PROGRESS, or progress.jsonl in a gotutor
+// directory under the user's configuration directory.
+func DefaultPath() (string, error) {
+	if p := os.Getenv("GOTUTOR_PROGRESS"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gotutor", "progress.jsonl"), nil
+}
+
+// Open opens the store at path, creating it and its directory if needed.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{path: path, file: f, now: time.Now}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// Add appends r to the store. If r.Time is zero, it is set to now.
+func (s *Store) Add(r Record) error {
+	if err := r.check(); err != nil {
+		return err
+	}
+	if r.Time.IsZero() {
+		r.Time = s.now()
+	}
+	r.Time = r.Time.UTC().Truncate(time.Second)
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Records reads every record in the store, oldest first. A last line with
+// no newline is a write still in progress, or one cut short by a crash,
+// and is left out.
+func (s *Store) Records() ([]Record, error) { return Read(s.path) }
+
+// Read reads every record in the store at path, as Records does, without
+// opening the store for writing. A store that does not exist yet has no
+// records.
+func Read(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i+1 < len(data) {
+		data = data[:i+1]
+	}
+
+	var records []Record
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		records = append(records, r)
+	}
+	return records, sc.Err()
+}
@@ -0,0 +1,193 @@
+package progress
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func open(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "sub", "progress.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStore(t *testing.T) {
+	s := open(t)
+	s.now = func() time.Time { return t0 }
+	want := []Record{
+		{Time: t0, Learner: "ana", Chapter: 2, Kind: Section, Name: "variables", Spent: Seconds(90 * time.Second)},
+		{Time: t0.Add(time.Minute), Learner: "ana", Chapter: 2, Kind: Exercise, Name: "fizzbuzz", Passed: true, Spent: Seconds(1234 * time.Millisecond)},
+	}
+	for _, r := range want {
+		if err := s.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, bad := range []Record{
+		{Learner: "", Chapter: 2, Kind: Section, Name: "x"},
+		{Learner: " ana", Chapter: 2, Kind: Section, Name: "x"},
+		{Learner: "ana\n", Chapter: 2, Kind: Section, Name: "x"},
+		{Learner: "ana", Chapter: 2, Kind: "page", Name: "x"},
+		{Learner: "ana", Chapter: 2, Kind: Section},
+		{Learner: "ana", Chapter: 2, Kind: Section, Name: "x", Spent: Seconds(2 * time.Hour)},
+	} {
+		if err := s.Add(bad); err == nil {
+			t.Errorf("Add(%+v) succeeded", bad)
+		}
+	}
+
+	// A line cut short by a crash is left out.
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"time":"2026-03-01T09:05:00Z","learner":"a`)
+	f.Close()
+
+	got, err := s.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Records() = %+v, want %+v", got, want)
+	}
+
+	data, _ := os.ReadFile(s.path)
+	line := strings.SplitN(string(data), "\n", 2)[0]
+	if wantLine := `{"time":"2026-03-01T09:00:00Z","learner":"ana","chapter":2,"kind":"section","name":"variables","spent":90}`; line != wantLine {
+		t.Errorf("first line is\n%s\nwant\n%s", line, wantLine)
+	}
+}
+
+func TestRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gotutor", "progress.jsonl")
+	records, err := Read(path)
+	if err != nil || records != nil {
+		t.Errorf("Read of a missing store = %v, %v", records, err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("Read created %s", filepath.Dir(path))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	at := func(min int) time.Time { return t0.Add(time.Duration(min) * time.Minute) }
+	records := []Record{
+		{Time: at(0), Learner: "ben", Chapter: 8, Kind: Section, Name: "arrays", Spent: Seconds(5 * time.Minute)},
+		{Time: at(10), Learner: "ben", Chapter: 8, Kind: Exercise, Name: "set-union", Spent: Seconds(time.Second)},
+		{Time: at(30), Learner: "ben", Chapter: 8, Kind: Exercise, Name: "set-union", Passed: true, Spent: Seconds(2 * time.Second)},
+		{Time: at(40), Learner: "ben", Chapter: 8, Kind: Exercise, Name: "set-union", Spent: Seconds(time.Second)},
+		{Time: at(41), Learner: "ben", Chapter: 8, Kind: Exercise, Name: "word-count", Passed: true, Spent: Seconds(1500 * time.Millisecond)},
+		{Time: at(5), Learner: "ben", Chapter: 8, Kind: Section, Name: "slices", Spent: Seconds(4 * time.Minute)},
+		{Time: at(6), Learner: "ben", Chapter: 8, Kind: Section, Name: "arrays", Spent: Seconds(time.Minute)},
+		{Time: at(1), Learner: "ana", Chapter: 9, Kind: Exercise, Name: "parse-version"},
+		{Time: at(4), Learner: "ana", Chapter: 9, Kind: Exercise, Name: "parse-version"},
+		{Time: at(2), Learner: "ana", Chapter: 2, Kind: Section, Name: "variables"},
+	}
+	got := Summarize(records)
+	want := []*Progress{
+		{Learner: "ana", Chapter: 2, Sections: []string{"variables"}},
+		{Learner: "ana", Chapter: 9, Exercises: []ExerciseProgress{
+			{Name: "parse-version", Attempts: 2, Spent: 3 * time.Minute},
+		}},
+		{Learner: "ben", Chapter: 8, Sections: []string{"arrays", "slices"}, Reading: 10 * time.Minute, Exercises: []ExerciseProgress{
+			{Name: "set-union", Attempts: 3, Passed: true, Spent: 20*time.Minute + 3*time.Second},
+			{Name: "word-count", Attempts: 1, Passed: true, Spent: 1500 * time.Millisecond},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		for _, p := range got {
+			t.Logf("%+v", *p)
+		}
+		t.Fatal("Summarize gave the wrong progress")
+	}
+	if d, want := got[2].Spent(), 30*time.Minute+4500*time.Millisecond; d != want {
+		t.Errorf("Spent() = %v, want %v", d, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s := open(t)
+	h := &Handler{Store: s, Sections: map[int][]string{12: {"goroutines", "channels"}}}
+	s.Add(Record{Learner: "ana", Chapter: 12, Kind: Exercise, Name: "merge-channels", Passed: true})
+
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/api/progress", strings.NewReader(body)))
+		return rec.Code
+	}
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{`{"learner":"ana","chapter":12,"section":"channels","spent":75.5}`, http.StatusNoContent},
+		{`{"learner":"ana","chapter":12,"section":"goroutines","spent":1e9}`, http.StatusNoContent},
+		{`{"learner":"ana","chapter":12,"section":"mutexes"}`, http.StatusBadRequest},
+		{`{"learner":"ana","chapter":3,"section":"channels"}`, http.StatusBadRequest},
+		{`{"learner":"","chapter":12,"section":"channels"}`, http.StatusBadRequest},
+		{`{"learner":"ana","chapter":12,"section":"channels"`, http.StatusBadRequest},
+		{`{"learner":"ana","chapter":12,"section":"channels","spent":"long"}`, http.StatusBadRequest},
+	} {
+		if got := post(tt.body); got != tt.code {
+			t.Errorf("POST %s: status %d, want %d", tt.body, got, tt.code)
+		}
+	}
+
+	records, err := s.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spent []time.Duration
+	for _, r := range records[1:] {
+		spent = append(spent, time.Duration(r.Spent))
+	}
+	if want := []time.Duration{75500 * time.Millisecond, MaxSpent}; !reflect.DeepEqual(spent, want) {
+		t.Errorf("time spent recorded as %v, want %v", spent, want)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/progress?learner=ana&chapter=12", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET: status %d: %s", rec.Code, rec.Body)
+	}
+	var resp chapterProgress
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := chapterProgress{
+		Learner:   "ana",
+		Chapter:   12,
+		Sections:  []string{"channels", "goroutines"},
+		Exercises: []exerciseProgress{{"merge-channels", 1, true}},
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("GET = %+v, want %+v", resp, want)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/progress?learner=ben&chapter=12", nil))
+	if want := `{"learner":"ben","chapter":12,"sections":[],"exercises":[]}` + "\n"; rec.Body.String() != want {
+		t.Errorf("no progress: got %s, want %s", rec.Body, want)
+	}
+
+	for _, q := range []string{"", "?learner=ana", "?chapter=12", "?learner=ana&chapter=x"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/progress"+q, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %q: status %d, want 400", q, rec.Code)
+		}
+	}
+}
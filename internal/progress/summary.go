@@ -0,0 +1,100 @@
+package progress
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Progress is one learner's progress through one chapter.
+type Progress struct {
+	Learner   string
+	Chapter   int
+	Sections  []string      // IDs of the sections read, in the order first read
+	Reading   time.Duration // time spent reading them
+	Exercises []ExerciseProgress
+}
+
+// ExerciseProgress sums up the attempts at one exercise.
+type ExerciseProgress struct {
+	Name     string
+	Attempts int
+	Passed   bool
+	// Spent is the time from the first attempt to the first pass or,
+	// while the exercise has not passed, to the latest attempt, plus the
+	// time the grader took for each of those attempts.
+	Spent time.Duration
+}
+
+// Spent returns the time spent on the chapter: reading plus exercises.
+func (p *Progress) Spent() time.Duration {
+	d := p.Reading
+	for _, e := range p.Exercises {
+		d += e.Spent
+	}
+	return d
+}
+
+// Read reports whether the learner has read the section with the given ID.
+func (p *Progress) Read(id string) bool {
+	return slices.Contains(p.Sections, id)
+}
+
+// Summarize groups records by learner and chapter. The result is sorted by
+// learner, then chapter.
+func Summarize(records []Record) []*Progress {
+	records = slices.Clone(records)
+	slices.SortStableFunc(records, func(a, b Record) int { return a.Time.Compare(b.Time) })
+
+	type key struct {
+		learner string
+		chapter int
+	}
+	byKey := make(map[key]*Progress)
+	var all []*Progress
+	// For each exercise, when it was first tried and how long its grader
+	// has taken up to the first pass.
+	type attempts struct {
+		first   time.Time
+		grading time.Duration
+	}
+	tries := make(map[key]map[string]*attempts)
+	for _, r := range records {
+		k := key{r.Learner, r.Chapter}
+		p := byKey[k]
+		if p == nil {
+			p = &Progress{Learner: r.Learner, Chapter: r.Chapter}
+			byKey[k] = p
+			all = append(all, p)
+			tries[k] = make(map[string]*attempts)
+		}
+
+		switch r.Kind {
+		case Section:
+			if !p.Read(r.Name) {
+				p.Sections = append(p.Sections, r.Name)
+			}
+			p.Reading += time.Duration(r.Spent)
+		case Exercise:
+			i := slices.IndexFunc(p.Exercises, func(e ExerciseProgress) bool { return e.Name == r.Name })
+			if i < 0 {
+				p.Exercises = append(p.Exercises, ExerciseProgress{Name: r.Name})
+				i = len(p.Exercises) - 1
+				tries[k][r.Name] = &attempts{first: r.Time}
+			}
+			e := &p.Exercises[i]
+			e.Attempts++
+			if !e.Passed {
+				e.Passed = r.Passed
+				a := tries[k][r.Name]
+				a.grading += time.Duration(r.Spent)
+				e.Spent = r.Time.Sub(a.first) + a.grading
+			}
+		}
+	}
+
+	slices.SortFunc(all, func(a, b *Progress) int {
+		return cmp.Or(cmp.Compare(a.Learner, b.Learner), cmp.Compare(a.Chapter, b.Chapter))
+	})
+	return all
+}
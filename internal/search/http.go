@@ -0,0 +1,38 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultHits = 10
+	maxHits     = 50
+)
+
+// ServeHTTP answers GET ?q=query&n=count with a JSON object holding the
+// query and its hits.
+func (ix *Index) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		http.Error(w, "missing q parameter", http.StatusBadRequest)
+		return
+	}
+	n := defaultHits
+	if s := r.URL.Query().Get("n"); s != "" {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil || n < 1 {
+			http.Error(w, "n must be a positive number", http.StatusBadRequest)
+			return
+		}
+		n = min(n, maxHits)
+	}
+
+	hits := ix.Search(q, n)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Query string `json:"query"`
+		Hits  []Hit  `json:"hits"`
+	}{q, hits})
+}
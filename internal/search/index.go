@@ -0,0 +1,310 @@
+// Package search is a full-text index over the tutorial: the sections of
+// the markdown chapters and the functions of the example code.
+//
+// Prose and Go code are split into terms differently. Prose becomes
+// lower-case words; Go code (fenced go blocks, `code spans` and the
+// example files) becomes identifiers, their mixed-case parts and qualified
+// names such as sync.once, so a search for "sync.Once" finds the sections
+// that use it rather than every mention of "once". Hits are ranked by BM25.
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sumit-covlant/go_tutorial/internal/site"
+)
+
+// BM25 parameters, at their usual values.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Hit is one search result.
+type Hit struct {
+	Title   string  `json:"title"`
+	URL     string  `json:"url,omitempty"` // page on the docs site, if there is one
+	File    string  `json:"file"`
+	Line    int     `json:"line"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// document is a chapter section or an example function.
+type document struct {
+	title string
+	url   string
+	file  string
+	line  int      // of lines[0]
+	lines []string // the text, for snippets
+	size  int      // number of terms
+}
+
+type posting struct {
+	doc  int
+	freq int
+}
+
+// Index is an inverted index over the tutorial.
+type Index struct {
+	docs     []document
+	postings map[string][]posting
+	avgSize  float64
+}
+
+// Build indexes the chapters and example code in content, which must hold
+// the go_tutorial directory.
+func Build(content fs.FS) (*Index, error) {
+	ix := &Index{postings: make(map[string][]posting)}
+
+	mdFiles, err := fs.Glob(content, "go_tutorial/*.md")
+	if err != nil {
+		return nil, err
+	}
+	if len(mdFiles) == 0 {
+		return nil, fmt.Errorf("no chapters found in go_tutorial/")
+	}
+	chapterURL := make(map[int]string)
+	for _, file := range mdFiles {
+		src, err := fs.ReadFile(content, file)
+		if err != nil {
+			return nil, err
+		}
+		c, err := site.RenderChapter(file, src)
+		if err != nil {
+			return nil, err
+		}
+		chapterURL[c.Number] = c.URL()
+		ix.addChapter(file, c, strings.Split(string(src), "\n"))
+	}
+
+	goFiles, err := fs.Glob(content, "go_tutorial/ch*/*.go")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range goFiles {
+		// sections.go only lists the functions the other files define, and
+		// the exercise graders and solutions are not for reading ahead.
+		if strings.HasSuffix(file, "_test.go") || strings.HasSuffix(file, "_solution.go") || path.Base(file) == "sections.go" {
+			continue
+		}
+		src, err := fs.ReadFile(content, file)
+		if err != nil {
+			return nil, err
+		}
+		num, _ := strconv.Atoi(strings.TrimPrefix(path.Base(path.Dir(file)), "ch"))
+		if err := ix.addGoFile(file, src, chapterURL[num]); err != nil {
+			return nil, err
+		}
+	}
+
+	total := 0
+	for _, d := range ix.docs {
+		total += d.size
+	}
+	ix.avgSize = float64(total) / float64(len(ix.docs))
+	return ix, nil
+}
+
+// addChapter adds the text before the first level-2 heading and each
+// level-2 section as documents.
+func (ix *Index) addChapter(file string, c *site.Chapter, lines []string) {
+	start, title, url := 1, c.Title, c.URL()
+	for _, h := range c.Sections {
+		ix.add(document{title: title, url: url, file: file, line: start, lines: lines[start-1 : h.Line-1]}, markdownTerms)
+		start, title, url = h.Line, c.Title+" › "+h.Text, c.URL()+"#"+h.ID
+	}
+	ix.add(document{title: title, url: url, file: file, line: start, lines: lines[start-1:]}, markdownTerms)
+}
+
+// addGoFile adds each function declared in a Go file as a document.
+func (ix *Index) addGoFile(file string, src []byte, url string) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+	pkg := path.Base(path.Dir(file))
+	lines := strings.Split(string(src), "\n")
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		first, last := fset.Position(start).Line, fset.Position(fn.End()).Line
+		ix.add(document{
+			title: pkg + "." + fn.Name.Name,
+			url:   url,
+			file:  file,
+			line:  first,
+			lines: lines[first-1 : last],
+		}, func(lines []string) []string {
+			return codeTerms(nil, strings.Join(lines, "\n"))
+		})
+	}
+	return nil
+}
+
+func (ix *Index) add(d document, terms func([]string) []string) {
+	freq := make(map[string]int)
+	for _, t := range terms(d.lines) {
+		freq[t]++
+		d.size++
+	}
+	if d.size == 0 {
+		return
+	}
+	id := len(ix.docs)
+	ix.docs = append(ix.docs, d)
+	for t, n := range freq {
+		ix.postings[t] = append(ix.postings[t], posting{id, n})
+	}
+}
+
+// markdownTerms splits markdown into terms: fenced go blocks and code
+// spans as Go code, everything else as prose.
+func markdownTerms(lines []string) []string {
+	var terms []string
+	var code strings.Builder
+	inFence, goFence := false, false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence, ok := strings.CutPrefix(trimmed, "```"); ok {
+			if inFence && goFence {
+				terms = codeTerms(terms, code.String())
+				code.Reset()
+			}
+			inFence = !inFence
+			goFence = inFence && (fence == "go" || fence == "golang")
+			continue
+		}
+		switch {
+		case goFence:
+			code.WriteString(line)
+			code.WriteByte('\n')
+		case inFence:
+			terms = proseTerms(terms, line)
+		default:
+			// Odd-numbered pieces between backquotes are code spans.
+			for i, piece := range strings.Split(line, "`") {
+				if i%2 == 1 {
+					terms = codeTerms(terms, piece)
+				} else {
+					terms = proseTerms(terms, piece)
+				}
+			}
+		}
+	}
+	if goFence {
+		terms = codeTerms(terms, code.String())
+	}
+	return terms
+}
+
+// Search returns up to n hits for query, best first, and none if n < 1.
+func (ix *Index) Search(query string, n int) []Hit {
+	if n < 1 {
+		return nil
+	}
+	terms := queryTerms(query)
+	slices.Sort(terms)
+	terms = slices.Compact(terms)
+
+	scores := make(map[int]float64)
+	numDocs := float64(len(ix.docs))
+	for _, t := range terms {
+		ps := ix.postings[t]
+		df := float64(len(ps))
+		idf := math.Log(1 + (numDocs-df+0.5)/(df+0.5))
+		for _, p := range ps {
+			tf := float64(p.freq)
+			norm := 1 - b + b*float64(ix.docs[p.doc].size)/ix.avgSize
+			scores[p.doc] += idf * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+
+	ids := make([]int, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(x, y int) int {
+		if c := cmp.Compare(scores[y], scores[x]); c != 0 {
+			return c
+		}
+		return cmp.Compare(x, y)
+	})
+	if len(ids) > n {
+		ids = ids[:n]
+	}
+
+	hits := make([]Hit, len(ids))
+	for i, id := range ids {
+		d := &ix.docs[id]
+		line, snippet := d.snippet(query)
+		hits[i] = Hit{
+			Title:   d.title,
+			URL:     d.url,
+			File:    d.file,
+			Line:    line,
+			Snippet: snippet,
+			Score:   math.Round(scores[id]*1000) / 1000,
+		}
+	}
+	return hits
+}
+
+const snippetLen = 120
+
+// snippet returns the first of the lines of d that mention the most words
+// of the query, or else its first line of text, shortened to snippetLen
+// runes.
+func (d *document) snippet(query string) (line int, text string) {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		if w = strings.Trim(w, "`\"'()"); w != "" {
+			words = append(words, w)
+		}
+	}
+	pick, best := -1, 0
+	for i, l := range d.lines {
+		lower, n := strings.ToLower(l), 0
+		for _, w := range words {
+			if strings.Contains(lower, w) {
+				n++
+			}
+		}
+		if n > best {
+			pick, best = i, n
+		}
+	}
+	if pick < 0 {
+		for i, l := range d.lines {
+			if t := strings.TrimSpace(l); t != "" && !strings.HasPrefix(t, "#") {
+				pick = i
+				break
+			}
+		}
+	}
+	if pick < 0 {
+		return d.line, ""
+	}
+	text = strings.TrimSpace(d.lines[pick])
+	if r := []rune(text); len(r) > snippetLen {
+		text = string(r[:snippetLen-1]) + "…"
+	}
+	return d.line + pick, text
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+var testContent = fstest.MapFS{
+	"go_tutorial/12_concurrency.md": {Data: []byte(`# Concurrency
+
+Goroutines and channels.
+
+## Doing Things Once
+
+Use ` + "`sync.Once`" + ` to initialise something exactly once.
+
+## Mutexes
+
+Once you share memory, guard it:
+
+` + "```go" + `
+var mu sync.RWMutex
+mu.RLock()
+` + "```" + `
+
+Once more, once again: once.
+`)},
+	"go_tutorial/ch12/12_concurrency_examples.go": {Data: []byte(`package ch12
+
+import "errors"
+
+// checkErrors shows errors.As.
+func checkErrors(err error) bool {
+	var target *MyError
+	return errors.As(err, &target)
+}
+`)},
+	"go_tutorial/ch12/sections.go":           {Data: []byte("package ch12\n\nfunc sections() { checkErrors(nil) }\n")},
+	"go_tutorial/ch12/golden_test.go":        {Data: []byte("package ch12\n\nfunc TestOnce() {}\n")},
+	"go_tutorial/ch12/exercises_solution.go": {Data: []byte("package ch12\n\nfunc Merge() {}\n")},
+}
+
+func TestSplitIdent(t *testing.T) {
+	for ident, want := range map[string]string{
+		"RWMutex":          "[RW Mutex]",
+		"HTTPServer":       "[HTTP Server]",
+		"waitGroupExample": "[wait Group Example]",
+		"worker_pool":      "[worker pool]",
+		"x":                "[x]",
+	} {
+		if got := fmt.Sprint(splitIdent(ident)); got != want {
+			t.Errorf("splitIdent(%q) = %s, want %s", ident, got, want)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := fmt.Sprint(codeTerms(nil, `mu.RLock() // Take the read lock`))
+	if want := "[mu rlock lock mu.rlock take read lock]"; got != want {
+		t.Errorf("codeTerms = %s, want %s", got, want)
+	}
+	got = fmt.Sprint(markdownTerms([]string{"Use `errors.As` to check it."}))
+	if want := "[errors as errors.as check]"; got != want {
+		t.Errorf("markdownTerms = %s, want %s", got, want)
+	}
+	got = fmt.Sprint(queryTerms("How to use errors.As with RWMutex?"))
+	if want := "[errors.as rwmutex]"; got != want {
+		t.Errorf("queryTerms = %s, want %s", got, want)
+	}
+}
+
+func build(t *testing.T) *Index {
+	t.Helper()
+	ix, err := Build(testContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ix
+}
+
+func TestSearch(t *testing.T) {
+	ix := build(t)
+	for _, tt := range []struct {
+		query string
+		want  []string // titles, best first
+	}{
+		// The section that uses sync.Once, not the one that says "once" most.
+		{"sync.Once", []string{"Concurrency › Doing Things Once"}},
+		{"once", []string{"Concurrency › Doing Things Once", "Concurrency › Mutexes"}},
+		{"RWMutex", []string{"Concurrency › Mutexes"}},
+		{"mutex", []string{"Concurrency › Mutexes"}},
+		{"errors.As", []string{"ch12.checkErrors"}},
+		{"goroutines", []string{"Concurrency"}},
+		{"the", nil},
+		{"merge", nil}, // solutions are left out
+		{"nothing like it", nil},
+	} {
+		hits := ix.Search(tt.query, 10)
+		var got []string
+		for _, h := range hits {
+			got = append(got, h.Title)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+	for _, n := range []int{0, -1} {
+		if hits := ix.Search("RWMutex", n); len(hits) != 0 {
+			t.Errorf("Search(%q, %d) = %v, want no hits", "RWMutex", n, hits)
+		}
+	}
+	if hits := ix.Search("once", 1); len(hits) != 1 {
+		t.Errorf("Search(%q, 1) returned %d hits", "once", len(hits))
+	}
+}
+
+func TestHitLocation(t *testing.T) {
+	ix := build(t)
+	want := []Hit{
+		{Title: "Concurrency › Mutexes", URL: "/chapters/concurrency#mutexes", File: "go_tutorial/12_concurrency.md", Line: 14, Snippet: "var mu sync.RWMutex"},
+		{Title: "ch12.checkErrors", URL: "/chapters/concurrency", File: "go_tutorial/ch12/12_concurrency_examples.go", Line: 7, Snippet: "var target *MyError"},
+	}
+	for i, query := range []string{"RWMutex", "target"} {
+		hits := ix.Search(query, 1)
+		if len(hits) != 1 {
+			t.Fatalf("Search(%q) found %d hits", query, len(hits))
+		}
+		got := hits[0]
+		got.Score = 0
+		if got != want[i] {
+			t.Errorf("Search(%q) = %+v, want %+v", query, got, want[i])
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	ix := build(t)
+
+	rec := httptest.NewRecorder()
+	ix.ServeHTTP(rec, httptest.NewRequest("GET", "/api/search?q=RWMutex&n=1", nil))
+	if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || ct != "application/json" {
+		t.Fatalf("status %d, Content-Type %q", rec.Code, ct)
+	}
+	var resp struct {
+		Query string
+		Hits  []Hit
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Query != "RWMutex" || len(resp.Hits) != 1 || resp.Hits[0].URL != "/chapters/concurrency#mutexes" {
+		t.Errorf("got %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	ix.ServeHTTP(rec, httptest.NewRequest("GET", "/api/search?q=zzz", nil))
+	if want := `{"query":"zzz","hits":[]}` + "\n"; rec.Body.String() != want {
+		t.Errorf("no hits: got %s, want %s", rec.Body, want)
+	}
+
+	for _, q := range []string{"", "?q=", "?q=x&n=0", "?q=x&n=lots"} {
+		rec := httptest.NewRecorder()
+		ix.ServeHTTP(rec, httptest.NewRequest("GET", "/api/search"+q, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %q: status %d, want 400", q, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"go/scanner"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// stopWords are common English words left out of the index.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "do": true, "for": true, "from": true,
+	"has": true, "have": true, "how": true, "if": true, "in": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "use": true, "used": true,
+	"using": true, "was": true, "what": true, "when": true, "which": true,
+	"will": true, "with": true, "you": true, "your": true,
+}
+
+// proseTerms appends the words of natural-language text, lower-cased.
+// Single letters and stop words are dropped.
+func proseTerms(terms []string, text string) []string {
+	for _, w := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		w = strings.ToLower(w)
+		if len(w) > 1 && !stopWords[w] {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// codeTerms appends the terms of Go source: every identifier, the parts of
+// a mixed-case identifier (RWMutex gives rwmutex, rw and mutex), and each
+// qualified name such as errors.as. Keywords and operators are skipped.
+// Comments and string literals are prose and go through proseTerms.
+func codeTerms(terms []string, code string) []string {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	s.Init(file, []byte(code), func(token.Position, string) {}, scanner.ScanComments)
+
+	var prev, prevPrev token.Token
+	var prevIdent string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch tok {
+		case token.IDENT:
+			if lit == "_" {
+				break
+			}
+			terms = append(terms, identTerms(lit)...)
+			if prev == token.PERIOD && prevPrev == token.IDENT {
+				terms = append(terms, qualified(prevIdent, lit))
+			}
+			prevIdent = lit
+		case token.COMMENT, token.STRING, token.CHAR:
+			terms = proseTerms(terms, lit)
+		}
+		prevPrev, prev = prev, tok
+	}
+	return terms
+}
+
+// queryTerms splits a query into terms the same way codeTerms does, except
+// that a qualified name such as errors.As stands only for itself, and a
+// lone word that is a stop word is dropped.
+func queryTerms(query string) []string {
+	var terms []string
+	for _, f := range strings.Fields(query) {
+		f = strings.Trim(f, "`\"'()")
+		if pkg, name, ok := strings.Cut(f, "."); ok && isIdent(pkg) && isIdent(name) {
+			terms = append(terms, qualified(pkg, name))
+			continue
+		}
+		for _, w := range strings.FieldsFunc(f, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		}) {
+			w = strings.ToLower(w)
+			if len(w) > 1 && !stopWords[w] {
+				terms = append(terms, w)
+			}
+		}
+	}
+	return terms
+}
+
+func qualified(pkg, name string) string {
+	return strings.ToLower(pkg) + "." + strings.ToLower(name)
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// identTerms returns an identifier lower-cased, followed by its parts when
+// it has more than one: "RWMutex" gives "rwmutex", "rw", "mutex";
+// "worker_pool" gives "worker_pool", "worker", "pool".
+func identTerms(ident string) []string {
+	terms := []string{strings.ToLower(ident)}
+	parts := splitIdent(ident)
+	if len(parts) > 1 {
+		for _, p := range parts {
+			if len(p) > 1 {
+				terms = append(terms, strings.ToLower(p))
+			}
+		}
+	}
+	return terms
+}
+
+// splitIdent splits at underscores and case changes. A run of capitals
+// is one part, except for its last letter when a lower-case letter
+// follows, so "HTTPServer" is "HTTP", "Server".
+func splitIdent(ident string) []string {
+	var parts []string
+	for _, word := range strings.Split(ident, "_") {
+		rs := []rune(word)
+		start := 0
+		for i := 1; i < len(rs); i++ {
+			if unicode.IsUpper(rs[i]) && (!unicode.IsUpper(rs[i-1]) || i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+				parts = append(parts, string(rs[start:i]))
+				start = i
+			}
+		}
+		if start < len(rs) {
+			parts = append(parts, string(rs[start:]))
+		}
+	}
+	return parts
+}
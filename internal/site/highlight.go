@@ -0,0 +1,92 @@
+package site
+
+import (
+	"go/scanner"
+	"go/token"
+	"html"
+	"io"
+	"strings"
+)
+
+// predeclared are Go's predeclared identifiers, highlighted apart from
+// user-defined names.
+var predeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true,
+	"complex128": true, "error": true, "float32": true, "float64": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true,
+
+	"true": true, "false": true, "iota": true, "nil": true,
+
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+// highlightGo writes code as HTML, wrapping keywords, literals, comments
+// and predeclared names in <span class="..."> elements. The text between
+// tokens, including all white space, is copied unchanged, so code that does
+// not scan cleanly still comes out whole.
+func highlightGo(w io.Writer, code []byte) {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	s.Init(file, code, func(token.Position, string) {}, scanner.ScanComments)
+
+	prev := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		class := tokenClass(tok, lit)
+		if class == "" {
+			continue
+		}
+		// A token's text is its literal, or for keywords its spelling.
+		start := file.Offset(pos)
+		length := len(lit)
+		if length == 0 {
+			length = len(tok.String())
+		}
+		end := start + length
+		if start < prev || end > len(code) {
+			continue
+		}
+		io.WriteString(w, html.EscapeString(string(code[prev:start])))
+		io.WriteString(w, `<span class="`+class+`">`)
+		io.WriteString(w, html.EscapeString(string(code[start:end])))
+		io.WriteString(w, `</span>`)
+		prev = end
+	}
+	io.WriteString(w, html.EscapeString(string(code[prev:])))
+}
+
+func tokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok == token.COMMENT:
+		return "c"
+	case tok == token.STRING || tok == token.CHAR:
+		return "s"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "n"
+	case tok.IsKeyword():
+		return "k"
+	case tok == token.IDENT && predeclared[lit]:
+		return "b"
+	}
+	return ""
+}
+
+// highlight writes code as HTML, highlighted if the language is one it
+// knows.
+func highlight(w io.Writer, lang string, code []byte) {
+	switch strings.ToLower(lang) {
+	case "go", "golang":
+		highlightGo(w, code)
+	default:
+		io.WriteString(w, html.EscapeString(string(code)))
+	}
+}
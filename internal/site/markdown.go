@@ -0,0 +1,164 @@
+package site
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Chapter is one rendered markdown chapter.
+type Chapter struct {
+	Number   int
+	Slug     string // file name without the number and extension, e.g. "concurrency"
+	File     string // e.g. "12_concurrency.md"
+	Title    string // text of the first level-1 heading
+	Sections []Heading
+	HTML     []byte
+}
+
+// Heading is a level-2 heading, which the sidebar links to.
+type Heading struct {
+	ID   string
+	Text string
+	Line int // in the markdown file, counting from 1
+}
+
+// URL returns the path the chapter is served at.
+func (c *Chapter) URL() string { return "/chapters/" + c.Slug }
+
+// parseChapterName splits a file name such as "12_concurrency.md" into its
+// number and slug.
+func parseChapterName(file string) (int, string, bool) {
+	name, ok := strings.CutSuffix(path.Base(file), ".md")
+	if !ok {
+		return 0, "", false
+	}
+	num, slug, ok := strings.Cut(name, "_")
+	if !ok || slug == "" {
+		return 0, "", false
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil || n < 0 {
+		return 0, "", false
+	}
+	return n, slug, true
+}
+
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(
+		// The chapters were written for a renderer that kept single line
+		// breaks; keep them so lists of short lines still read as lines.
+		html.WithHardWraps(),
+		renderer.WithNodeRenderers(util.Prioritized(codeRenderer{}, 100)),
+	),
+)
+
+// RenderChapter converts a chapter's markdown to HTML and collects the
+// headings for the sidebar. The file name must have the form N_slug.md.
+func RenderChapter(file string, src []byte) (*Chapter, error) {
+	num, slug, ok := parseChapterName(file)
+	if !ok {
+		return nil, fmt.Errorf("%s: chapter files are named N_slug.md", file)
+	}
+	c := &Chapter{Number: num, Slug: slug, File: path.Base(file)}
+
+	doc := markdown.Parser().Parse(text.NewReader(src))
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		switch {
+		case h.Level == 1 && c.Title == "":
+			c.Title = plainText(h, src)
+		case h.Level == 2:
+			id, _ := h.AttributeString("id")
+			idBytes, _ := id.([]byte)
+			line := 1
+			if h.Lines().Len() > 0 {
+				line += bytes.Count(src[:h.Lines().At(0).Start], []byte("\n"))
+			}
+			c.Sections = append(c.Sections, Heading{ID: string(idBytes), Text: plainText(h, src), Line: line})
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.Title == "" {
+		c.Title = slug
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	c.HTML = buf.Bytes()
+	return c, nil
+}
+
+// plainText returns the text of an inline container with the markup
+// removed, e.g. "The go Tool" for "The `go` Tool".
+func plainText(n ast.Node, src []byte) string {
+	var b strings.Builder
+	ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(src))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
+
+// codeRenderer renders fenced code blocks with syntax highlighting, in
+// place of goldmark's plain <pre><code>.
+type codeRenderer struct{}
+
+func (codeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, renderFencedCode)
+}
+
+func renderFencedCode(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+	lang := string(n.Language(src))
+
+	var code bytes.Buffer
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		code.Write(line.Value(src))
+	}
+
+	w.WriteString("<pre><code")
+	if lang != "" {
+		fmt.Fprintf(w, ` class="language-%s"`, util.EscapeHTML([]byte(lang)))
+	}
+	w.WriteString(">")
+	highlight(w, lang, code.Bytes())
+	w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
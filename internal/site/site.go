@@ -0,0 +1,209 @@
+// Package site serves the tutorial as a web site.
+//
+// The markdown chapters in go_tutorial/ are rendered to HTML once, when the
+// Site is created, with Go code highlighted on the server. Every response
+// is one of those prepared pages or an embedded asset looked up by exact
+// name, so no part of a request URL is ever used as a file path.
+package site
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+)
+
+//go:embed static templates
+var embedded embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(embedded, "templates/page.html"))
+
+// resource is a prepared response body.
+type resource struct {
+	body        []byte
+	contentType string
+	etag        string
+}
+
+func newResource(body []byte, contentType string) *resource {
+	sum := sha256.Sum256(body)
+	return &resource{
+		body:        body,
+		contentType: contentType,
+		etag:        `"` + hex.EncodeToString(sum[:12]) + `"`,
+	}
+}
+
+// serve writes r, or 304 Not Modified if the client's If-None-Match
+// already names its ETag.
+func (r *resource) serve(w http.ResponseWriter, req *http.Request) {
+	h := w.Header()
+	h.Set("Content-Type", r.contentType)
+	h.Set("ETag", r.etag)
+	// Revalidate every time; the ETag makes that cheap.
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(r.body))
+}
+
+// Site is an http.Handler for the tutorial.
+type Site struct {
+	chapters []*Chapter
+	index    *resource
+	pages    map[string]*resource // by chapter slug
+	assets   map[string]*resource // by file name under /static/
+	run      http.Handler
+	mux      *http.ServeMux
+}
+
+// New renders the chapters found in content, which must hold the
+// go_tutorial directory. If run is not nil, it handles POST /run, and the
+// pages offer to run their Go programs through it.
+func New(content fs.FS, run http.Handler) (*Site, error) {
+	files, err := fs.Glob(content, "go_tutorial/*.md")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no chapters found in go_tutorial/")
+	}
+
+	s := &Site{
+		pages:  make(map[string]*resource),
+		assets: make(map[string]*resource),
+		run:    run,
+		mux:    http.NewServeMux(),
+	}
+	for _, file := range files {
+		src, err := fs.ReadFile(content, file)
+		if err != nil {
+			return nil, err
+		}
+		c, err := RenderChapter(file, src)
+		if err != nil {
+			return nil, err
+		}
+		s.chapters = append(s.chapters, c)
+	}
+	slices.SortFunc(s.chapters, func(a, b *Chapter) int { return cmp.Compare(a.Number, b.Number) })
+
+	if err := s.renderPages(); err != nil {
+		return nil, err
+	}
+	if err := s.loadAssets(); err != nil {
+		return nil, err
+	}
+	s.routes()
+	return s, nil
+}
+
+// Chapters returns the chapters in order.
+func (s *Site) Chapters() []*Chapter { return s.chapters }
+
+func (s *Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Site) routes() {
+	s.mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		s.index.serve(w, r)
+	})
+	s.mux.HandleFunc("GET /chapters/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		s.serveFrom(s.pages, r.PathValue("slug"), w, r)
+	})
+	s.mux.HandleFunc("GET /static/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.serveFrom(s.assets, r.PathValue("name"), w, r)
+	})
+	s.mux.HandleFunc("GET /favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		s.assets["covlant_icon.png"].serve(w, r)
+	})
+	if s.run != nil {
+		s.mux.Handle("POST /run", s.run)
+	}
+}
+
+func (s *Site) serveFrom(m map[string]*resource, key string, w http.ResponseWriter, r *http.Request) {
+	res, ok := m[key]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	res.serve(w, r)
+}
+
+// pageData is what templates/page.html renders.
+type pageData struct {
+	Chapters []*Chapter
+	Current  *Chapter // nil on the index page
+	Prev     *Chapter
+	Next     *Chapter
+	Content  template.HTML
+	Run      bool // whether POST /run is available
+}
+
+func (s *Site) renderPages() error {
+	render := func(i int) (*resource, error) {
+		data := pageData{Chapters: s.chapters, Run: s.run != nil}
+		if i >= 0 {
+			current := s.chapters[i]
+			data.Current = current
+			if i > 0 {
+				data.Prev = s.chapters[i-1]
+			}
+			if i+1 < len(s.chapters) {
+				data.Next = s.chapters[i+1]
+			}
+			// The chapter HTML comes from goldmark with raw HTML disabled.
+			data.Content = template.HTML(current.HTML)
+		}
+		var buf bytes.Buffer
+		if err := pageTemplate.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		return newResource(buf.Bytes(), "text/html; charset=utf-8"), nil
+	}
+
+	var err error
+	if s.index, err = render(-1); err != nil {
+		return err
+	}
+	for i, c := range s.chapters {
+		if _, dup := s.pages[c.Slug]; dup {
+			return fmt.Errorf("two chapters have the slug %q", c.Slug)
+		}
+		if s.pages[c.Slug], err = render(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAssets prepares every file in the embedded static directory. Only
+// these names are served under /static/.
+func (s *Site) loadAssets() error {
+	entries, err := fs.ReadDir(embedded, "static")
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		body, err := fs.ReadFile(embedded, path.Join("static", e.Name()))
+		if err != nil {
+			return err
+		}
+		ctype := mime.TypeByExtension(path.Ext(e.Name()))
+		if ctype == "" {
+			ctype = http.DetectContentType(body)
+		}
+		s.assets[e.Name()] = newResource(body, ctype)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package site
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var testContent = fstest.MapFS{
+	"go_tutorial/0_guide.md": {Data: []byte("# Go Learning Guide\n\nStart here.\n")},
+	"go_tutorial/1_introduction.md": {Data: []byte(
+		"# Introduction to `Go`\n\n" +
+			"## Why Go?\n\nBecause.\n\n" +
+			"## Hello, World\n\n" +
+			"```go\n// greet\nfunc main() { fmt.Println(\"hi <b>\", 42, nil) }\n```\n\n" +
+			"```bash\necho <hi>\n```\n")},
+	"go_tutorial/1_introduction.go": {Data: []byte("package main\n")},
+	"server.js":                     {Data: []byte("secret")},
+	"go.mod":                        {Data: []byte("module x\n")},
+}
+
+func newTestSite(t *testing.T) *Site {
+	t.Helper()
+	s, err := New(testContent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func get(s *Site, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.URL.Path = path // unescaped, as a hostile client might send it
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestChapters(t *testing.T) {
+	s := newTestSite(t)
+	cs := s.Chapters()
+	if len(cs) != 2 {
+		t.Fatalf("got %d chapters, want 2", len(cs))
+	}
+	c := cs[1]
+	if c.Number != 1 || c.Slug != "introduction" || c.Title != "Introduction to Go" {
+		t.Errorf("chapter 1 = %d %q %q", c.Number, c.Slug, c.Title)
+	}
+	want := []Heading{{"why-go", "Why Go?", 3}, {"hello-world", "Hello, World", 7}}
+	if len(c.Sections) != len(want) {
+		t.Fatalf("sections = %v, want %v", c.Sections, want)
+	}
+	for i := range want {
+		if c.Sections[i] != want[i] {
+			t.Errorf("section %d = %v, want %v", i, c.Sections[i], want[i])
+		}
+	}
+}
+
+func TestChapterPage(t *testing.T) {
+	s := newTestSite(t)
+	rec := get(s, "/chapters/introduction")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<a href="/chapters/introduction" class="nav-link active">1. Introduction to Go</a>`,
+		`<a href="#why-go" class="nav-link">Why Go?</a>`,
+		`<h2 id="hello-world">Hello, World</h2>`,
+		`<pre><code class="language-go"><span class="c">// greet</span>`,
+		`<span class="k">func</span> main()`,
+		`<span class="s">&#34;hi &lt;b&gt;&#34;</span>`,
+		`<span class="n">42</span>`,
+		`<span class="b">nil</span>`,
+		`<pre><code class="language-bash">echo &lt;hi&gt;`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page lacks %s", want)
+		}
+	}
+}
+
+func TestOnlyKnownPathsAreServed(t *testing.T) {
+	s := newTestSite(t)
+	for _, path := range []string{
+		"/server.js",
+		"/go.mod",
+		"/go_tutorial/1_introduction.md",
+		"/chapters/1_introduction.go",
+		"/chapters/nope",
+		"/static/../go.mod",
+		"/static/%2e%2e/go.mod",
+		"/../go.mod",
+		"/static/",
+	} {
+		rec := get(s, path)
+		if rec.Code == http.StatusOK {
+			t.Errorf("GET %s: status 200", path)
+		}
+		if strings.Contains(rec.Body.String(), "module x") || strings.Contains(rec.Body.String(), "secret") {
+			t.Errorf("GET %s leaked a file", path)
+		}
+	}
+}
+
+func TestAssets(t *testing.T) {
+	s := newTestSite(t)
+	for path, ctype := range map[string]string{
+		"/static/style.css":        "text/css; charset=utf-8",
+		"/static/app.js":           "text/javascript; charset=utf-8",
+		"/static/covlant_icon.png": "image/png",
+		"/favicon.ico":             "image/png",
+	} {
+		rec := get(s, path)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: status %d", path, rec.Code)
+			continue
+		}
+		if got := rec.Header().Get("Content-Type"); got != ctype {
+			t.Errorf("GET %s: Content-Type = %q, want %q", path, got, ctype)
+		}
+		if rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+			t.Errorf("GET %s: no nosniff header", path)
+		}
+	}
+}
+
+func TestETag(t *testing.T) {
+	s := newTestSite(t)
+	rec := get(s, "/")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("GET /: status %d, ETag %q", rec.Code, etag)
+	}
+	if rec := get(s, "/", "If-None-Match", etag); rec.Code != http.StatusNotModified {
+		t.Errorf("GET / with matching If-None-Match: status %d, want 304", rec.Code)
+	}
+	if rec := get(s, "/", "If-None-Match", `"stale"`); rec.Code != http.StatusOK {
+		t.Errorf("GET / with stale If-None-Match: status %d, want 200", rec.Code)
+	}
+}
+
+func TestRunHandler(t *testing.T) {
+	if rec := get(newTestSite(t), "/"); strings.Contains(rec.Body.String(), "data-run") {
+		t.Error("page offers to run code without a run handler")
+	}
+
+	run := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ran"))
+	})
+	s, err := New(testContent, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := get(s, "/chapters/introduction"); !strings.Contains(rec.Body.String(), `<body data-run="/run">`) {
+		t.Error("page does not offer to run code")
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/run", nil))
+	if rec.Body.String() != "ran" {
+		t.Errorf("POST /run = %d %q", rec.Code, rec.Body)
+	}
+}
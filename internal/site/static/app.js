@@ -0,0 +1,288 @@
+// The pages are complete without this script, which adds, each part in its
+// own function below:
+//
+//   - a menu button that opens and closes the sidebar on narrow screens;
+//   - Run buttons on whole programs, when gotutor serve -run allows it;
+//   - search as you type;
+//   - progress tracking, ticking off the sections a reader has read.
+//
+// Each part does nothing if the page lacks what it needs.
+
+// Open and close the sidebar on narrow screens.
+(function () {
+    const button = document.querySelector('.menu-button');
+    const sidebar = document.getElementById('sidebar');
+    if (!button || !sidebar) {
+        return;
+    }
+
+    function setOpen(open) {
+        sidebar.classList.toggle('open', open);
+        button.setAttribute('aria-expanded', String(open));
+    }
+
+    button.addEventListener('click', (e) => {
+        e.stopPropagation();
+        setOpen(!sidebar.classList.contains('open'));
+    });
+
+    // Close when a link is followed or the page is clicked elsewhere.
+    sidebar.addEventListener('click', (e) => {
+        if (e.target.closest('a')) {
+            setOpen(false);
+        }
+    });
+    document.addEventListener('click', (e) => {
+        if (!sidebar.contains(e.target)) {
+            setOpen(false);
+        }
+    });
+})();
+
+// When the server can run code (gotutor serve -run), every Go block that is
+// a whole program gets a Run button. The output streams back as
+// server-sent events, which are read from the POST response directly since
+// EventSource can only GET.
+(function () {
+    const endpoint = document.body.dataset.run;
+    if (!endpoint) {
+        return;
+    }
+
+    document.querySelectorAll('pre > code.language-go').forEach((code) => {
+        const src = code.textContent;
+        if (!/^package main\b/m.test(src) || !/^func main\(\)/m.test(src)) {
+            return;
+        }
+        const pre = code.parentElement;
+        const button = document.createElement('button');
+        button.type = 'button';
+        button.className = 'run-button';
+        button.textContent = 'Run';
+        pre.before(button);
+
+        button.addEventListener('click', async () => {
+            let out = pre.nextElementSibling;
+            if (!out || !out.classList.contains('run-output')) {
+                out = document.createElement('pre');
+                out.className = 'run-output';
+                pre.after(out);
+            }
+            out.textContent = '';
+            button.disabled = true;
+            try {
+                await run(endpoint, { code: src }, (name, data) => {
+                    if (name === 'done' || name === 'error') {
+                        data = '\n[' + data + ']';
+                    }
+                    const span = document.createElement('span');
+                    span.className = name;
+                    span.textContent = data;
+                    out.append(span);
+                });
+            } catch (err) {
+                out.append('\n[' + err.message + ']');
+            } finally {
+                button.disabled = false;
+            }
+        });
+    });
+
+    async function run(url, body, onEvent) {
+        const res = await fetch(url, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(body),
+        });
+        if (!res.ok) {
+            throw new Error(await res.text());
+        }
+        const reader = res.body.pipeThrough(new TextDecoderStream()).getReader();
+        let buf = '';
+        for (;;) {
+            const { value, done } = await reader.read();
+            if (done) {
+                return;
+            }
+            buf += value;
+            let end;
+            while ((end = buf.indexOf('\n\n')) >= 0) {
+                dispatch(buf.slice(0, end), onEvent);
+                buf = buf.slice(end + 2);
+            }
+        }
+    }
+
+    function dispatch(block, onEvent) {
+        let name = 'message';
+        const data = [];
+        for (const line of block.split('\n')) {
+            if (line.startsWith('event: ')) {
+                name = line.slice(7);
+            } else if (line.startsWith('data: ')) {
+                data.push(line.slice(6));
+            }
+        }
+        onEvent(name, data.join('\n'));
+    }
+})();
+
+// Search as you type, through the JSON API that gotutor serve provides.
+// Without the script the form still submits to the API and shows its JSON.
+(function () {
+    const form = document.querySelector('form.search');
+    const input = form && form.querySelector('input');
+    const results = form && form.querySelector('.search-results');
+    if (!input || !results) {
+        return;
+    }
+    let pending;
+
+    async function update() {
+        const q = input.value.trim();
+        if (!q) {
+            results.hidden = true;
+            return;
+        }
+        const res = await fetch(form.action + '?' + new URLSearchParams({ q, n: 8 }));
+        if (!res.ok || q !== input.value.trim()) {
+            return;
+        }
+        const { hits } = await res.json();
+        results.replaceChildren(...hits.map((hit) => {
+            const li = document.createElement('li');
+            const title = document.createElement(hit.url ? 'a' : 'span');
+            title.className = 'title';
+            title.textContent = hit.title;
+            if (hit.url) {
+                title.href = hit.url;
+            }
+            const where = document.createElement('small');
+            where.textContent = hit.file + ':' + hit.line;
+            const snippet = document.createElement('code');
+            snippet.textContent = hit.snippet;
+            li.append(title, where, snippet);
+            return li;
+        }));
+        if (hits.length === 0) {
+            const li = document.createElement('li');
+            li.textContent = 'No matches';
+            results.append(li);
+        }
+        results.hidden = false;
+    }
+
+    input.addEventListener('input', () => {
+        clearTimeout(pending);
+        pending = setTimeout(update, 150);
+    });
+    form.addEventListener('submit', (e) => {
+        e.preventDefault();
+        update();
+    });
+    document.addEventListener('click', (e) => {
+        if (!form.contains(e.target)) {
+            results.hidden = true;
+        }
+    });
+})();
+
+// Progress tracking, through the API that gotutor serve provides. Once the
+// reader gives a name, a section counts as read when they scroll on to the
+// next heading, or to the end of the chapter, and the sidebar ticks it off.
+// The name is kept in this browser only.
+(function () {
+    const article = document.querySelector('article[data-chapter]');
+    const links = new Map();
+    document.querySelectorAll('.sections a').forEach((a) => {
+        links.set(decodeURIComponent(a.hash.slice(1)), a);
+    });
+    if (!article || links.size === 0) {
+        return;
+    }
+    const endpoint = '/api/progress';
+    const chapter = Number(article.dataset.chapter);
+    const key = 'gotutor-learner';
+    const box = document.createElement('div');
+    box.className = 'learner';
+    document.getElementById('sidebar').append(box);
+
+    let learner = localStorage.getItem(key);
+    let observer;
+    if (learner) {
+        track();
+    } else {
+        ask();
+    }
+
+    function ask() {
+        const form = document.createElement('form');
+        const input = document.createElement('input');
+        input.required = true;
+        input.maxLength = 64;
+        input.placeholder = 'Your name';
+        input.setAttribute('aria-label', 'Your name');
+        const button = document.createElement('button');
+        button.textContent = 'Track my progress';
+        form.append(input, button);
+        box.replaceChildren(form);
+        form.addEventListener('submit', (e) => {
+            e.preventDefault();
+            learner = input.value.trim();
+            if (learner) {
+                localStorage.setItem(key, learner);
+                track();
+            }
+        });
+    }
+
+    async function track() {
+        const change = document.createElement('button');
+        change.type = 'button';
+        change.textContent = 'change';
+        change.addEventListener('click', () => {
+            localStorage.removeItem(key);
+            observer.disconnect();
+            links.forEach((a) => a.classList.remove('done'));
+            ask();
+        });
+        box.replaceChildren('Tracking progress as ' + learner + ' (', change, ')');
+
+        const res = await fetch(endpoint + '?' + new URLSearchParams({ learner, chapter }));
+        if (!res.ok) {
+            box.textContent = 'Progress tracking is not available.';
+            return;
+        }
+        const { sections } = await res.json();
+        sections.forEach((id) => links.get(id)?.classList.add('done'));
+
+        // Reaching the heading of section i+1 means section i was read; the
+        // pager at the bottom closes the last one.
+        const ids = [...links.keys()];
+        const ends = ids.slice(1).map((id) => document.getElementById(id));
+        ends.push(document.querySelector('.pager'));
+        let since = Date.now();
+        observer = new IntersectionObserver((entries) => {
+            for (const entry of entries) {
+                const i = ends.indexOf(entry.target);
+                if (entry.isIntersecting && i >= 0 && !links.get(ids[i]).classList.contains('done')) {
+                    markRead(ids[i], (Date.now() - since) / 1000);
+                    since = Date.now();
+                }
+            }
+        });
+        ends.forEach((el) => el && observer.observe(el));
+    }
+
+    async function markRead(id, spent) {
+        links.get(id).classList.add('done');
+        const res = await fetch(endpoint, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ learner, chapter, section: id, spent }),
+        });
+        if (!res.ok) {
+            links.get(id).classList.remove('done');
+        }
+    }
+})();
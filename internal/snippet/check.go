@@ -0,0 +1,382 @@
+package snippet
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxProblemsPerBlock caps how many errors are reported for one block, as
+// the compiler does.
+const maxProblemsPerBlock = 10
+
+// Problem is a compile error in a block, positioned in the markdown file.
+type Problem struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Msg)
+}
+
+// Result summarizes the check of one markdown file.
+type Result struct {
+	Blocks   int // ```go blocks found
+	Skipped  int // blocks opted out with SkipMarker
+	External []Block
+	Failed   int // blocks with at least one problem
+	Problems []Problem
+}
+
+// Checker type-checks blocks. A Checker caches imported packages, so reuse
+// one across files.
+type Checker struct {
+	fset     *token.FileSet
+	importer types.Importer
+}
+
+// NewChecker returns a Checker that imports the standard library from the
+// local Go installation.
+func NewChecker() *Checker {
+	fset := token.NewFileSet()
+	return &Checker{
+		fset:     fset,
+		importer: importer.ForCompiler(fset, "gc", nil),
+	}
+}
+
+// CheckFile extracts and checks every ```go block in a markdown file.
+//
+// Fragments may use names that an earlier or later block of the same file
+// declares, the way the prose builds on what it has shown. Each block is
+// checked together with the file's other top-level declarations, and only
+// errors inside the block itself are reported.
+func (c *Checker) CheckFile(file string, src []byte) Result {
+	var res Result
+	var units []*unit
+
+	for _, b := range Extract(file, src) {
+		res.Blocks++
+		if b.Skip {
+			res.Skipped++
+			continue
+		}
+		u, err := wrap(c.fset, b)
+		if err != nil {
+			res.Failed++
+			res.Problems = append(res.Problems, parseProblems(file, err)...)
+			continue
+		}
+		if usesExternal(u.file) {
+			res.External = append(res.External, b)
+			continue
+		}
+		units = append(units, u)
+	}
+
+	decls := collectDecls(units)
+	for _, u := range units {
+		if problems := c.check(u, decls); len(problems) > 0 {
+			res.Failed++
+			res.Problems = append(res.Problems, problems...)
+		}
+	}
+	// Parse errors are found before type errors, so put the file back in
+	// line order.
+	slices.SortStableFunc(res.Problems, func(a, b Problem) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return res
+}
+
+func parseProblems(file string, err error) []Problem {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []Problem{{File: file, Msg: err.Error()}}
+	}
+	// The parser can report the same spot more than once, and not always
+	// in order; keep the first error on each line, as the compiler does.
+	list.Sort()
+	list.RemoveMultiples()
+	var problems []Problem
+	for _, e := range list {
+		problems = append(problems, Problem{File: file, Line: e.Pos.Line, Msg: e.Msg})
+		if len(problems) == maxProblemsPerBlock {
+			break
+		}
+	}
+	return problems
+}
+
+// check type-checks one unit against the shared declarations of its file.
+func (c *Checker) check(u *unit, decls []decl) []Problem {
+	files := []*ast.File{u.file}
+	if ctx := c.contextFile(u, decls); ctx != nil {
+		files = append(files, ctx)
+	}
+	if u.shape != shapeFile {
+		addImports(u.file)
+	}
+
+	var problems []Problem
+	conf := types.Config{
+		Importer:    c.importer,
+		FakeImportC: true,
+		Error: func(err error) {
+			terr, ok := err.(types.Error)
+			if !ok || len(problems) == maxProblemsPerBlock {
+				return
+			}
+			pos := c.fset.Position(terr.Pos)
+			if pos.Filename != u.block.File {
+				return // inside the shared declarations
+			}
+			line := u.mdLine(pos.Line)
+			if line == 0 || tolerated(u.shape, terr.Msg) {
+				return
+			}
+			// go/types follows "x redeclared" with a second error,
+			// "\tother declaration of x", at the first declaration.
+			if strings.HasPrefix(terr.Msg, "\t") {
+				if n := len(problems); n > 0 {
+					problems[n-1].Msg += fmt.Sprintf(" (other declaration on line %d)", line)
+				}
+				return
+			}
+			problems = append(problems, Problem{File: u.block.File, Line: line, Msg: terr.Msg})
+		},
+	}
+	conf.Check(u.file.Name.Name, c.fset, files, nil)
+	return problems
+}
+
+// tolerated reports whether an error is an artifact of checking a fragment
+// on its own rather than a mistake in it.
+func tolerated(s shape, msg string) bool {
+	if s == shapeFile {
+		return false
+	}
+	// Fragments often show an import block without all the code that
+	// would use it.
+	if strings.Contains(msg, "imported") && strings.HasSuffix(msg, "and not used") {
+		return true
+	}
+	if s == shapeDecls {
+		return false
+	}
+	for _, prefix := range []string{
+		"declared and not used",
+		"too many return values",
+		"not enough return values",
+		"label ",
+	} {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// decl is a top-level declaration that other blocks of the same file may
+// refer to. Fragments count as package main; complete files share
+// declarations with other blocks that declare the same package.
+type decl struct {
+	pkg  string
+	keys []string // names it declares; methods are "Type.Method"
+	src  string
+	from *unit
+}
+
+func collectDecls(units []*unit) []decl {
+	var decls []decl
+	seen := make(map[string]bool)
+	for _, u := range units {
+		if u.shape == shapeStmts {
+			continue
+		}
+		pkg := u.file.Name.Name
+		for _, d := range u.file.Decls {
+			keys := declKeys(d)
+			if len(keys) == 0 || slices.ContainsFunc(keys, func(k string) bool { return seen[pkg+"."+k] }) {
+				continue
+			}
+			for _, k := range keys {
+				seen[pkg+"."+k] = true
+			}
+			start, end := int(d.Pos()-u.file.FileStart), int(d.End()-u.file.FileStart)
+			decls = append(decls, decl{pkg: pkg, keys: keys, src: u.src[start:end], from: u})
+		}
+	}
+	return decls
+}
+
+func declKeys(d ast.Decl) []string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		name := d.Name.Name
+		if d.Recv == nil {
+			if name == "main" || name == "init" || name == "_" {
+				return nil
+			}
+			return []string{name}
+		}
+		return []string{receiverType(d.Recv.List[0].Type) + "." + name}
+	case *ast.GenDecl:
+		if d.Tok == token.IMPORT {
+			return nil
+		}
+		var keys []string
+		for _, spec := range d.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				keys = append(keys, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range spec.Names {
+					if n.Name != "_" {
+						keys = append(keys, n.Name)
+					}
+				}
+			}
+		}
+		return keys
+	}
+	return nil
+}
+
+func receiverType(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// contextFile builds a file holding the declarations from other blocks that
+// u does not declare itself.
+func (c *Checker) contextFile(u *unit, decls []decl) *ast.File {
+	own := make(map[string]bool)
+	for _, d := range u.file.Decls {
+		for _, k := range declKeys(d) {
+			own[k] = true
+		}
+	}
+
+	pkg := u.file.Name.Name
+	var src strings.Builder
+	src.WriteString("package " + pkg + "\n")
+	n := 0
+	for _, d := range decls {
+		if d.pkg != pkg || d.from == u || slices.ContainsFunc(d.keys, func(k string) bool { return own[k] }) {
+			continue
+		}
+		src.WriteString(d.src)
+		src.WriteString("\n")
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	f, err := parser.ParseFile(c.fset, "<context>", src.String(), 0)
+	if err != nil {
+		return nil
+	}
+	addImports(f)
+	return f
+}
+
+// addImports adds an import for each standard library package the file
+// refers to without importing it.
+func addImports(f *ast.File) {
+	imported := make(map[string]bool)
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imported[name] = true
+	}
+
+	var missing []string
+	for _, id := range unresolvedPackages(f) {
+		if path, ok := stdlib[id]; ok && !imported[id] {
+			imported[id] = true
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	gen := &ast.GenDecl{Tok: token.IMPORT}
+	for _, path := range missing {
+		spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)}}
+		gen.Specs = append(gen.Specs, spec)
+		f.Imports = append(f.Imports, spec)
+	}
+	f.Decls = append([]ast.Decl{gen}, f.Decls...)
+}
+
+// unresolvedPackages returns the identifiers used as the X in X.Sel that
+// the parser could not resolve inside the file; those are package names.
+func unresolvedPackages(f *ast.File) []string {
+	unresolved := make(map[*ast.Ident]bool)
+	for _, id := range f.Unresolved {
+		unresolved[id] = true
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && unresolved[id] && !seen[id.Name] {
+			seen[id.Name] = true
+			names = append(names, id.Name)
+		}
+		return true
+	})
+	return names
+}
+
+// usesExternal reports whether a file imports, or refers by its usual name
+// to, a package outside the standard library. Those blocks cannot be
+// checked without downloading modules.
+func usesExternal(f *ast.File) bool {
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		first, _, _ := strings.Cut(path, "/")
+		if strings.Contains(first, ".") {
+			return true
+		}
+	}
+	for _, name := range unresolvedPackages(f) {
+		if external[name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+// Package snippet extracts the ```go code blocks from the markdown chapters
+// and type-checks them.
+//
+// Most blocks are fragments: a few declarations, or a handful of statements
+// that lean on a function shown earlier in the chapter. Check wraps each one
+// in a synthetic package main, infers the standard library imports it needs
+// and runs go/types over it. Blocks that are meant as pseudo-code can opt out
+// by putting the marker
+//
+//	<!-- snippetcheck: skip -->
+//
+// on the line just before the opening fence.
+package snippet
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// SkipMarker, on the line before a ```go fence, excludes that block from
+// checking.
+const SkipMarker = "<!-- snippetcheck: skip -->"
+
+// Block is one ```go fenced block from a markdown file.
+type Block struct {
+	File string // markdown file the block came from
+	Line int    // line number of the first line of code
+	Code string
+	Skip bool // preceded by SkipMarker
+}
+
+// Extract returns the ```go blocks in src, in document order.
+func Extract(file string, src []byte) []Block {
+	var (
+		blocks  []Block
+		current *Block
+		code    strings.Builder
+		prev    string
+		lineNo  int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case current == nil && isGoFence(trimmed):
+			current = &Block{
+				File: file,
+				Line: lineNo + 1,
+				Skip: strings.TrimSpace(prev) == SkipMarker,
+			}
+			code.Reset()
+		case current != nil && trimmed == "```":
+			current.Code = code.String()
+			blocks = append(blocks, *current)
+			current = nil
+		case current != nil:
+			code.WriteString(line)
+			code.WriteByte('\n')
+		}
+		prev = line
+	}
+	return blocks
+}
+
+func isGoFence(line string) bool {
+	info, ok := strings.CutPrefix(line, "```")
+	if !ok {
+		return false
+	}
+	fields := strings.Fields(info)
+	return len(fields) > 0 && fields[0] == "go"
+}
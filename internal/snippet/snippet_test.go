@@ -0,0 +1,98 @@
+package snippet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	md := strings.Join([]string{
+		"# Title",        // 1
+		"```go",          // 2
+		"x := 1",         // 3
+		"```",            // 4
+		"```bash",        // 5
+		"go run .",       // 6
+		"```",            // 7
+		SkipMarker,       // 8
+		"```go",          // 9
+		"func f(a) b {}", // 10
+		"```",            // 11
+	}, "\n")
+
+	blocks := Extract("doc.md", []byte(md))
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+	if b := blocks[0]; b.Line != 3 || b.Code != "x := 1\n" || b.Skip {
+		t.Errorf("first block = %+v", b)
+	}
+	if b := blocks[1]; b.Line != 10 || !b.Skip {
+		t.Errorf("second block = %+v", b)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want []string // problems, as "line: message" prefixes
+	}{
+		{
+			name: "statements with inferred imports",
+			md:   "```go\nname := strings.ToUpper(\"go\")\nfmt.Println(name)\n```\n",
+		},
+		{
+			name: "declarations",
+			md:   "```go\ntype Celsius float64\n\nfunc (c Celsius) String() string {\n    return fmt.Sprintf(\"%.1f°C\", float64(c))\n}\n```\n",
+		},
+		{
+			name: "declarations then statements",
+			md:   "```go\nfunc add(a, b int) int {\n    return a + b\n}\n\nresult := add(1, 2)\nfmt.Println(result)\n```\n",
+		},
+		{
+			name: "uses a function from another block",
+			md:   "```go\nfunc divide(a, b int) (int, error) {\n    return a / b, nil\n}\n```\n\n```go\nq, err := divide(10, 2)\nfmt.Println(q, err)\n```\n",
+		},
+		{
+			name: "type error",
+			md:   "Text\n\n```go\nvar n int = \"five\"\n```\n",
+			want: []string{`4: cannot use "five"`},
+		},
+		{
+			name: "parse error",
+			md:   "```go\nfunc f() {\n    fmt.Println(\"a\" \"b\")\n}\n```\n",
+			want: []string{"3: missing ','"},
+		},
+		{
+			name: "one parse error per line, in line order",
+			md:   "```go\nrequire (\n    example.com/a v1.0.0\n    example.com/b v1.0.0\n)\n```\n",
+			want: []string{"3: ", "5: "},
+		},
+		{
+			name: "complete file is not given imports",
+			md:   "```go\npackage main\n\nfunc main() {\n    fmt.Println(\"hi\")\n}\n```\n",
+			want: []string{"5: undefined: fmt"},
+		},
+		{
+			name: "skipped",
+			md:   SkipMarker + "\n```go\nfunc functionName(parameters) returnType {\n}\n```\n",
+		},
+	}
+
+	checker := NewChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := checker.CheckFile("doc.md", []byte(tt.md))
+			if len(res.Problems) != len(tt.want) {
+				t.Fatalf("got problems %v, want %q", res.Problems, tt.want)
+			}
+			for i, p := range res.Problems {
+				got := strings.TrimPrefix(p.String(), "doc.md:")
+				if !strings.HasPrefix(got, tt.want[i]) {
+					t.Errorf("problem %d = %q, want prefix %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
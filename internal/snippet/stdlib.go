@@ -0,0 +1,99 @@
+package snippet
+
+// stdlib maps the name a fragment uses for a standard library package to
+// its import path. Where two packages share a name (math/rand and
+// crypto/rand, text/template and html/template) the one the chapters use
+// most wins; a block can always import the other explicitly.
+var stdlib = map[string]string{
+	"atomic":    "sync/atomic",
+	"base64":    "encoding/base64",
+	"big":       "math/big",
+	"binary":    "encoding/binary",
+	"bufio":     "bufio",
+	"bytes":     "bytes",
+	"cmp":       "cmp",
+	"context":   "context",
+	"csv":       "encoding/csv",
+	"debug":     "runtime/debug",
+	"driver":    "database/sql/driver",
+	"errors":    "errors",
+	"exec":      "os/exec",
+	"filepath":  "path/filepath",
+	"flag":      "flag",
+	"fmt":       "fmt",
+	"fs":        "io/fs",
+	"gzip":      "compress/gzip",
+	"heap":      "container/heap",
+	"hex":       "encoding/hex",
+	"http":      "net/http",
+	"httptest":  "net/http/httptest",
+	"httputil":  "net/http/httputil",
+	"io":        "io",
+	"ioutil":    "io/ioutil",
+	"iter":      "iter",
+	"json":      "encoding/json",
+	"list":      "container/list",
+	"log":       "log",
+	"maps":      "maps",
+	"math":      "math",
+	"md5":       "crypto/md5",
+	"net":       "net",
+	"os":        "os",
+	"path":      "path",
+	"pprof":     "runtime/pprof",
+	"rand":      "math/rand",
+	"reflect":   "reflect",
+	"regexp":    "regexp",
+	"ring":      "container/ring",
+	"runtime":   "runtime",
+	"sha256":    "crypto/sha256",
+	"signal":    "os/signal",
+	"slices":    "slices",
+	"slog":      "log/slog",
+	"sort":      "sort",
+	"sql":       "database/sql",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"sync":      "sync",
+	"syscall":   "syscall",
+	"tabwriter": "text/tabwriter",
+	"template":  "html/template",
+	"testing":   "testing",
+	"time":      "time",
+	"tls":       "crypto/tls",
+	"trace":     "runtime/trace",
+	"unicode":   "unicode",
+	"unsafe":    "unsafe",
+	"url":       "net/url",
+	"utf8":      "unicode/utf8",
+	"xml":       "encoding/xml",
+}
+
+// external lists the usual names of third-party packages the chapters
+// mention. A block that uses one without importing it is still recognised
+// as needing modules that are not available offline.
+var external = map[string]bool{
+	"assert":      true, // github.com/stretchr/testify/assert
+	"chi":         true,
+	"cobra":       true,
+	"constraints": true, // golang.org/x/exp/constraints
+	"echo":        true,
+	"gin":         true,
+	"gomock":      true,
+	"gorm":        true,
+	"logrus":      true,
+	"mock":        true,
+	"mux":         true, // github.com/gorilla/mux
+	"negroni":     true,
+	"pq":          true,
+	"prometheus":  true,
+	"promauto":    true,
+	"redis":       true,
+	"require":     true,
+	"sqlx":        true,
+	"suite":       true,
+	"uuid":        true,
+	"viper":       true,
+	"websocket":   true,
+	"zap":         true,
+}
@@ -0,0 +1,207 @@
+package snippet
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// shape says how a block was wrapped to make it a compilable file.
+type shape int
+
+const (
+	shapeFile  shape = iota // a complete file with its own package clause
+	shapeDecls              // top-level declarations only
+	shapeStmts              // statements, wrapped in a function body
+	shapeMixed              // declarations plus loose statements
+)
+
+// unit is the synthetic source built from a block, with a map back to the
+// markdown lines it came from.
+type unit struct {
+	block Block
+	shape shape
+	src   string
+	lines []int // lines[i] is the markdown line of source line i+1, or 0 if synthetic
+	file  *ast.File
+}
+
+// mdLine maps a line of the synthetic source back to the markdown file.
+func (u *unit) mdLine(line int) int {
+	if line < 1 || line > len(u.lines) {
+		return 0
+	}
+	return u.lines[line-1]
+}
+
+type builder struct {
+	src   strings.Builder
+	lines []int
+}
+
+func (b *builder) add(text string, mdLine int) {
+	b.src.WriteString(text)
+	b.src.WriteByte('\n')
+	b.lines = append(b.lines, mdLine)
+}
+
+var declStart = regexp.MustCompile(`^(func|type|import|var|const)\b`)
+
+// wrap turns a block into a parsed Go file. It tries, in order, the block
+// as a whole file, as package-level declarations, as a function body and
+// as declarations followed by statements, and keeps the first that parses.
+func wrap(fset *token.FileSet, b Block) (*unit, error) {
+	code := strings.Split(strings.TrimRight(b.Code, "\n"), "\n")
+
+	if hasPackageClause(code) {
+		var bld builder
+		for i, line := range code {
+			bld.add(line, b.Line+i)
+		}
+		return parse(fset, b, shapeFile, &bld)
+	}
+
+	attempts := []func() *builder{
+		func() *builder {
+			var bld builder
+			bld.add("package main", 0)
+			for i, line := range code {
+				bld.add(line, b.Line+i)
+			}
+			return &bld
+		},
+		func() *builder {
+			var bld builder
+			bld.add("package main", 0)
+			bld.add("func _() {", 0)
+			for i, line := range code {
+				bld.add(line, b.Line+i)
+			}
+			bld.add("}", 0)
+			return &bld
+		},
+		func() *builder { return splitMixed(b, code) },
+	}
+	shapes := []shape{shapeDecls, shapeStmts, shapeMixed}
+
+	// Report the parse error that got furthest into the block; it is
+	// usually the one that points at the real mistake.
+	var best error
+	bestLine := -1
+	for i, attempt := range attempts {
+		u, err := parse(fset, b, shapes[i], attempt())
+		if err == nil {
+			return u, nil
+		}
+		if line := errorLine(err); line > bestLine {
+			best, bestLine = err, line
+		}
+	}
+	return nil, best
+}
+
+func parse(fset *token.FileSet, b Block, s shape, bld *builder) (*unit, error) {
+	u := &unit{block: b, shape: s, src: bld.src.String(), lines: bld.lines}
+	f, err := parser.ParseFile(fset, b.File, u.src, parser.ParseComments|parser.AllErrors)
+	if err != nil {
+		return nil, remap(u, err)
+	}
+	u.file = f
+	return u, nil
+}
+
+// remap rewrites the positions in a parse error to markdown lines.
+func remap(u *unit, err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+	out := make(scanner.ErrorList, 0, len(list))
+	for _, e := range list {
+		if line := u.mdLine(e.Pos.Line); line > 0 {
+			e.Pos.Line = line
+		} else {
+			// An error on a synthetic line, such as an unclosed brace
+			// reported at the wrapper's closing "}", belongs to the end of
+			// the block.
+			e.Pos.Line = u.block.Line + strings.Count(u.block.Code, "\n") - 1
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func errorLine(err error) int {
+	if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+		return list[0].Pos.Line
+	}
+	return 0
+}
+
+func hasPackageClause(code []string) bool {
+	for _, line := range code {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		return strings.HasPrefix(line, "package ")
+	}
+	return false
+}
+
+// splitMixed moves the top-level declarations of a block to package scope
+// and puts every other line in a function body. A declaration starts at a
+// line with no indentation that begins with a declaration keyword, and runs
+// until its brackets balance.
+func splitMixed(b Block, code []string) *builder {
+	var decls, stmts builder
+	decls.add("package main", 0)
+
+	depth := 0
+	inDecl := false
+	for i, line := range code {
+		if depth == 0 {
+			inDecl = declStart.MatchString(line)
+		}
+		depth += bracketDelta(line)
+		if depth < 0 {
+			depth = 0
+		}
+		if inDecl {
+			decls.add(line, b.Line+i)
+		} else {
+			stmts.add(line, b.Line+i)
+		}
+	}
+
+	decls.add("func _() {", 0)
+	decls.src.WriteString(stmts.src.String())
+	decls.lines = append(decls.lines, stmts.lines...)
+	decls.add("}", 0)
+	return &decls
+}
+
+// bracketDelta returns how much a line changes the bracket nesting depth,
+// ignoring brackets inside strings and comments.
+func bracketDelta(line string) int {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(line))
+	s.Init(file, []byte(line), func(token.Position, string) {}, 0)
+
+	delta := 0
+	for {
+		_, tok, _ := s.Scan()
+		switch tok {
+		case token.EOF:
+			return delta
+		case token.LBRACE, token.LPAREN, token.LBRACK:
+			delta++
+		case token.RBRACE, token.RPAREN, token.RBRACK:
+			delta--
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package tutor describes the runnable parts of a tutorial chapter.
+//
+// Every go_tutorial/chNN package exports a Chapter value that lists its
+// Main function and each of its example sections, so tools such as the
+// gotutor command can run them by name.
+package tutor
+
+import "fmt"
+
+// Section is a single named example function within a chapter.
+type Section struct {
+	Name string
+	Run  func()
+}
+
+// Exercise is a practice problem. The reader completes a stub in the
+// chapter's exercises.go, and a grader test, built only with the "grade"
+// tag, checks the result.
+type Exercise struct {
+	Name    string   // e.g. "set-union"; unique across chapters
+	Summary string   // one line saying what to implement
+	Test    string   // the grader, e.g. "TestSetUnion"
+	Hints   []string // shown when the grader fails
+}
+
+// Chapter groups the examples for one chapter of the tutorial.
+type Chapter struct {
+	Number   int
+	Slug     string // e.g. "concurrency", used in file and URL names
+	Title    string
+	Doc      string // markdown file in go_tutorial/, e.g. "12_concurrency.md"
+	Main     func() // runs every section in the order the chapter presents them
+	Sections []Section
+
+	// Workspace, if set, must be called before running a chapter whose
+	// examples work with files. It points them at dir, or at a new
+	// temporary directory if dir is "", and returns a function that
+	// removes what they created.
+	Workspace func(dir string) (release func() error, err error)
+
+	Exercises []Exercise
+}
+
+// Section returns the section with the given name.
+func (c Chapter) Section(name string) (Section, bool) {
+	for _, s := range c.Sections {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// Exercise returns the exercise with the given name.
+func (c Chapter) Exercise(name string) (Exercise, bool) {
+	for _, e := range c.Exercises {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Exercise{}, false
+}
+
+// Run runs the named section, or the whole chapter when name is empty.
+func (c Chapter) Run(name string) error {
+	if name == "" {
+		c.Main()
+		return nil
+	}
+	s, ok := c.Section(name)
+	if !ok {
+		return fmt.Errorf("chapter %d has no section %q", c.Number, name)
+	}
+	s.Run()
+	return nil
+}
+
+// String returns the chapter number and title, e.g. "12. Concurrency".
+func (c Chapter) String() string {
+	return fmt.Sprintf("%d. %s", c.Number, c.Title)
+}
@@ -0,0 +1,171 @@
+// Package typedid gives each kind of entity its own ID type, growing the
+// data types chapter's "type UserID int64" into one generic type: an
+// ID[User] and an ID[Order] are both int64s underneath, but passing one
+// where the other is wanted does not compile.
+//
+// An entity type may give its IDs a prefix by implementing Prefixed, so
+// that they are written as "usr_123" rather than "123". Then a string
+// naming an ID of some other entity, such as "ord_123", does not parse as
+// an ID[User] either.
+//
+// IDs are written as text by String and MarshalText, as JSON by
+// MarshalJSON, and to databases by Value, and read back by the matching
+// Parse, UnmarshalText, UnmarshalJSON and Scan. The zero ID is written as
+// empty text, JSON null and SQL NULL, and read back from them, so that a
+// struct whose ID has not been assigned yet survives the round trip.
+package typedid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ID identifies an entity of type T. The zero ID means "no ID".
+type ID[T any] int64
+
+// Prefixed is implemented by entity types whose IDs are written with a
+// prefix. IDPrefix returns it without the underscore, such as "usr". It
+// is called on the zero value of the type.
+type Prefixed interface {
+	IDPrefix() string
+}
+
+// Prefix returns the prefix that IDs of T are written with, or "" if they
+// are written as plain numbers.
+func Prefix[T any]() string {
+	var zero T
+	if p, ok := any(zero).(Prefixed); ok {
+		return p.IDPrefix()
+	}
+	if p, ok := any(&zero).(Prefixed); ok {
+		return p.IDPrefix()
+	}
+	return ""
+}
+
+// String returns the ID as text, such as "usr_123" or "123".
+func (id ID[T]) String() string {
+	n := strconv.FormatInt(int64(id), 10)
+	if p := Prefix[T](); p != "" {
+		return p + "_" + n
+	}
+	return n
+}
+
+// Int64 returns the ID as a number.
+func (id ID[T]) Int64() int64 { return int64(id) }
+
+// Parse parses an ID written as String writes it. The number must be
+// positive, and must carry T's prefix if it has one.
+func Parse[T any](s string) (ID[T], error) {
+	num := s
+	if p := Prefix[T](); p != "" {
+		var ok bool
+		if num, ok = strings.CutPrefix(s, p+"_"); !ok {
+			return 0, fmt.Errorf("typedid: %q is not an ID of the form %s_123", s, p)
+		}
+	}
+	// ParseInt would take a sign, which no ID is written with.
+	if num == "" || num[0] < '0' || num[0] > '9' {
+		return 0, fmt.Errorf("typedid: %q is not an ID", s)
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("typedid: %q is not an ID", s)
+	}
+	return ID[T](n), nil
+}
+
+// MarshalText writes the ID as String does, and the zero ID as no text.
+func (id ID[T]) MarshalText() ([]byte, error) {
+	if id == 0 {
+		return []byte{}, nil
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText reads an ID as Parse does, and no text as the zero ID.
+func (id *ID[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = 0
+		return nil
+	}
+	v, err := Parse[T](string(text))
+	if err == nil {
+		*id = v
+	}
+	return err
+}
+
+// MarshalJSON writes an ID as a JSON string if it has a prefix, and as a
+// number if not. It writes the zero ID as null.
+func (id ID[T]) MarshalJSON() ([]byte, error) {
+	if id == 0 {
+		return []byte("null"), nil
+	}
+	if Prefix[T]() != "" {
+		return json.Marshal(id.String())
+	}
+	return strconv.AppendInt(nil, int64(id), 10), nil
+}
+
+// UnmarshalJSON reads an ID as MarshalJSON writes it. An ID without a
+// prefix may also be a string, such as "123". Like Unmarshal itself, it
+// leaves the ID alone when data is null.
+func (id *ID[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return id.UnmarshalText([]byte(s))
+	}
+	if Prefix[T]() != "" {
+		return fmt.Errorf("typedid: want a string such as %q, got %s", ID[T](123).String(), data)
+	}
+	return id.UnmarshalText(data)
+}
+
+// Value stores the ID in a database as its number, as driver.Valuer
+// requires, and the zero ID as NULL.
+func (id ID[T]) Value() (driver.Value, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return int64(id), nil
+}
+
+// Scan reads an ID from a database column, as sql.Scanner requires. The
+// column may hold the number, or text as String writes it; NULL is read
+// as the zero ID.
+func (id *ID[T]) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		if v <= 0 {
+			return fmt.Errorf("typedid: %d is not an ID", v)
+		}
+		*id = ID[T](v)
+		return nil
+	case []byte:
+		return id.UnmarshalText(v)
+	case string:
+		return id.UnmarshalText([]byte(v))
+	case nil:
+		*id = 0
+		return nil
+	}
+	return fmt.Errorf("typedid: cannot scan %T into an ID", src)
+}
+
+// Sequence hands out IDs of T in order, starting at 1, as an in-memory
+// store might. It is safe for concurrent use; the zero value is ready.
+type Sequence[T any] struct {
+	last atomic.Int64
+}
+
+// Next returns the next ID.
+func (s *Sequence[T]) Next() ID[T] { return ID[T](s.last.Add(1)) }
@@ -0,0 +1,168 @@
+package typedid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+type user struct{}
+
+func (user) IDPrefix() string { return "usr" }
+
+type order struct{}
+
+func (*order) IDPrefix() string { return "ord" }
+
+type row struct{}
+
+var (
+	_ sql.Scanner   = (*ID[user])(nil)
+	_ driver.Valuer = ID[user](0)
+)
+
+func TestString(t *testing.T) {
+	if got := ID[user](123).String(); got != "usr_123" {
+		t.Errorf("ID[user] = %q", got)
+	}
+	if got := ID[order](7).String(); got != "ord_7" {
+		t.Errorf("ID[order] = %q", got)
+	}
+	if got := ID[row](42).String(); got != "42" {
+		t.Errorf("ID[row] = %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if id, err := Parse[user]("usr_123"); err != nil || id != 123 {
+		t.Errorf("Parse(usr_123) = %v, %v", id, err)
+	}
+	if id, err := Parse[row]("42"); err != nil || id != 42 {
+		t.Errorf("Parse(42) = %v, %v", id, err)
+	}
+	for _, s := range []string{"", "123", "ord_123", "usr_", "usr_0", "usr_-1", "usr_+1", "usr_1x", "usr_99999999999999999999", "USR_1"} {
+		if id, err := Parse[user](s); err == nil {
+			t.Errorf("Parse[user](%q) = %v, want an error", s, id)
+		}
+	}
+	for _, s := range []string{"usr_1", "-1", "0", "1.5"} {
+		if id, err := Parse[row](s); err == nil {
+			t.Errorf("Parse[row](%q) = %v, want an error", s, id)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type invoice struct {
+		ID    ID[order] `json:"id"`
+		Buyer ID[user]  `json:"buyer"`
+		Row   ID[row]   `json:"row"`
+	}
+	in := invoice{ID: 9, Buyer: 123, Row: 4}
+	data, err := json.Marshal(in)
+	if want := `{"id":"ord_9","buyer":"usr_123","row":4}`; err != nil || string(data) != want {
+		t.Errorf("Marshal = %s, %v; want %s", data, err, want)
+	}
+	var out invoice
+	if err := json.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("round trip gave %+v, %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"row":"4"}`), &out); err != nil || out.Row != 4 {
+		t.Errorf("a quoted ID[row] gave %v, %v", out.Row, err)
+	}
+	if err := json.Unmarshal([]byte(`{"row":""}`), &out); err != nil || out.Row != 0 {
+		t.Errorf("an empty ID[row] gave %v, %v", out.Row, err)
+	}
+	for _, bad := range []string{`{"buyer":123}`, `{"buyer":0}`, `{"buyer":"usr_0"}`, `{"row":0}`, `{"buyer":"ord_9"}`, `{"row":-4}`, `{"row":true}`} {
+		if err := json.Unmarshal([]byte(bad), &out); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", bad)
+		}
+	}
+}
+
+func TestZero(t *testing.T) {
+	type invoice struct {
+		ID    ID[order] `json:"id"`
+		Buyer ID[user]  `json:"buyer"`
+		Row   ID[row]   `json:"row"`
+	}
+	data, err := json.Marshal(invoice{})
+	if want := `{"id":null,"buyer":null,"row":null}`; err != nil || string(data) != want {
+		t.Errorf("Marshal = %s, %v; want %s", data, err, want)
+	}
+	out := invoice{ID: 9, Buyer: 123, Row: 4}
+	if err := json.Unmarshal(data, &out); err != nil || out != (invoice{ID: 9, Buyer: 123, Row: 4}) {
+		t.Errorf("null changed the IDs to %+v, %v", out, err)
+	}
+	out = invoice{}
+	if err := json.Unmarshal(data, &out); err != nil || out != (invoice{}) {
+		t.Errorf("round trip gave %+v, %v", out, err)
+	}
+
+	text, err := ID[user](0).MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+	id := ID[user](5)
+	if err := id.UnmarshalText(text); err != nil || id != 0 {
+		t.Errorf("UnmarshalText(%q) = %v, %v", text, id, err)
+	}
+
+	v, err := ID[user](0).Value()
+	if err != nil || v != nil {
+		t.Errorf("Value() = %v, %v; want NULL", v, err)
+	}
+	id = 5
+	if err := id.Scan(v); err != nil || id != 0 {
+		t.Errorf("Scan(NULL) = %v, %v", id, err)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	v, err := ID[user](5).Value()
+	if err != nil || v != int64(5) {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	for _, src := range []any{int64(5), []byte("usr_5"), "usr_5"} {
+		var id ID[user]
+		if err := id.Scan(src); err != nil || id != 5 {
+			t.Errorf("Scan(%#v) = %v, %v", src, id, err)
+		}
+	}
+	for _, src := range []any{int64(0), int64(-1), "usr_0", "ord_5", 5.0} {
+		var id ID[user]
+		if err := id.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) succeeded", src)
+		}
+	}
+	var n sql.Null[ID[user]]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("sql.Null.Scan(nil) = %+v, %v", n, err)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	var seq Sequence[user]
+	var wg sync.WaitGroup
+	seen := make([]bool, 101)
+	var mu sync.Mutex
+	for range 100 {
+		wg.Go(func() {
+			id := seq.Next()
+			mu.Lock()
+			seen[id] = true
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	for i := 1; i <= 100; i++ {
+		if !seen[i] {
+			t.Fatalf("ID %d was not handed out", i)
+		}
+	}
+	if id := seq.Next(); id != 101 {
+		t.Errorf("Next() = %v, want usr_101", id)
+	}
+}
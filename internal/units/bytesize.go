@@ -0,0 +1,212 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is an amount of data in bytes.
+//
+// It prints in IEC units, which are powers of 1024, as in "1.5 GiB"; SI
+// formats it in SI units, which are powers of 1000, as in "1.61 GB". Parse
+// takes either. A *ByteSize is a flag.Value, so a command can take sizes
+// as flags:
+//
+//	limit := units.MiB
+//	flag.Var(&limit, "limit", "largest upload")
+type ByteSize int64
+
+// Units of data. Note that KB is 1000 bytes, as in the SI; the KB of the
+// data types chapter, 1024 bytes, is KiB here.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+// MaxByteSize is the largest ByteSize, about 8 EiB.
+const MaxByteSize ByteSize = math.MaxInt64
+
+// ErrOverflow is returned when a size does not fit in a ByteSize.
+var ErrOverflow = errors.New("units: byte size out of range")
+
+var (
+	iecSizes = []unit{{"EiB", float64(EiB)}, {"PiB", float64(PiB)}, {"TiB", float64(TiB)}, {"GiB", float64(GiB)}, {"MiB", float64(MiB)}, {"KiB", float64(KiB)}}
+	siSizes  = []unit{{"EB", float64(EB)}, {"PB", float64(PB)}, {"TB", float64(TB)}, {"GB", float64(GB)}, {"MB", float64(MB)}, {"kB", float64(KB)}}
+)
+
+// String formats b in IEC units, to at most two decimal places, as in
+// "512 MiB", "1.5 GiB" or "100 B".
+func (b ByteSize) String() string { return b.format(iecSizes) }
+
+// SI formats b in SI units, to at most two decimal places, as in "1.5 GB".
+func (b ByteSize) SI() string { return b.format(siSizes) }
+
+func (b ByteSize) format(sizes []unit) string {
+	abs := math.Abs(float64(b))
+	for _, u := range sizes {
+		if abs >= u.size {
+			n := math.Round(float64(b)/u.size*100) / 100
+			return strconv.FormatFloat(n, 'f', -1, 64) + " " + u.symbol
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + " B"
+}
+
+// ParseByteSize parses a size such as "512MiB", "1.5 GB", "64 kB" or
+// "100". A number without a unit is bytes. Unit names are not case
+// sensitive. A fraction of a byte is rounded to the nearest byte; a
+// negative size is an error.
+func ParseByteSize(s string) (ByteSize, error) {
+	in := s
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexAny(s, "0123456789.") + 1
+	num, name := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:])
+
+	size := Byte
+	if name != "" && !strings.EqualFold(name, "B") {
+		found := false
+		for _, u := range slices.Concat(iecSizes, siSizes) {
+			if strings.EqualFold(name, u.symbol) {
+				size, found = ByteSize(u.size), true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("units: unknown unit %q in byte size %q", name, in)
+		}
+	}
+
+	if strings.HasPrefix(num, "-") {
+		return 0, fmt.Errorf("units: byte size %q is negative", in)
+	}
+	// Only plain decimals; big.Rat would also take "1/2", "1e3" and "0x10".
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || num == "" || strings.Trim(num, "0123456789.") != "" {
+		return 0, fmt.Errorf("units: cannot parse %q as a byte size", in)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(size)))
+	// Round half up to a whole number of bytes.
+	n := new(big.Int).Quo(new(big.Int).Add(new(big.Int).Mul(r.Num(), big.NewInt(2)), r.Denom()), new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, in)
+	}
+	return ByteSize(n.Int64()), nil
+}
+
+// Add returns b + c, or ErrOverflow if the sum does not fit.
+func (b ByteSize) Add(c ByteSize) (ByteSize, error) {
+	sum := b + c
+	if (sum > b) != (c > 0) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// Sub returns b - c, or ErrOverflow if the difference does not fit.
+func (b ByteSize) Sub(c ByteSize) (ByteSize, error) {
+	diff := b - c
+	if (diff < b) != (c > 0) {
+		return 0, ErrOverflow
+	}
+	return diff, nil
+}
+
+// Mul returns b * n, or ErrOverflow if the product does not fit.
+func (b ByteSize) Mul(n int64) (ByteSize, error) {
+	neg := (b < 0) != (n < 0)
+	hi, lo := bits.Mul64(absUint(int64(b)), absUint(n))
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+	if hi != 0 || lo > limit {
+		return 0, ErrOverflow
+	}
+	if neg {
+		return ByteSize(-lo), nil
+	}
+	return ByteSize(lo), nil
+}
+
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return -uint64(n)
+	}
+	return uint64(n)
+}
+
+// Set parses s into b; it makes *ByteSize a flag.Value.
+func (b *ByteSize) Set(s string) error {
+	v, err := ParseByteSize(s)
+	if err == nil {
+		*b = v
+	}
+	return err
+}
+
+// MarshalText writes the shortest of String, SI and a whole number of
+// bytes or of some unit that gives back exactly b when parsed, so a size
+// keeps its precision as text. Negative sizes, which UnmarshalText would
+// reject, are an error.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	if b < 0 {
+		return nil, fmt.Errorf("units: byte size %d is negative", int64(b))
+	}
+	best := strconv.FormatInt(int64(b), 10) + " B"
+	try := func(s string) {
+		if len(s) < len(best) {
+			if v, err := ParseByteSize(s); err == nil && v == b {
+				best = s
+			}
+		}
+	}
+	try(b.String())
+	try(b.SI())
+	for _, u := range slices.Concat(iecSizes, siSizes) {
+		if size := int64(u.size); int64(b)%size == 0 {
+			try(fmt.Sprintf("%d %s", int64(b)/size, u.symbol))
+		}
+	}
+	return []byte(best), nil
+}
+
+// UnmarshalText parses a size as ParseByteSize does.
+func (b *ByteSize) UnmarshalText(text []byte) error { return b.Set(string(text)) }
+
+// UnmarshalJSON accepts a string, as MarshalText writes, or a number of
+// bytes.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	var n int64
+	if err := json.Unmarshal(data, &n); err == nil {
+		if n < 0 {
+			return fmt.Errorf("units: byte size %d is negative", n)
+		}
+		*b = ByteSize(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("units: a byte size is a string or a number, not %s", data)
+	}
+	return b.Set(s)
+}
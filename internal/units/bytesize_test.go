@@ -0,0 +1,139 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestByteSizeString(t *testing.T) {
+	for _, tt := range []struct {
+		b     ByteSize
+		iec   string
+		si    string
+		marsh string
+	}{
+		{0, "0 B", "0 B", "0 B"},
+		{100, "100 B", "100 B", "100 B"},
+		{1023, "1023 B", "1.02 kB", "1023 B"},
+		{1536 * MiB, "1.5 GiB", "1.61 GB", "1.5 GiB"},
+		{512 * MiB, "512 MiB", "536.87 MB", "512 MiB"},
+		{1500 * MB, "1.4 GiB", "1.5 GB", "1.5 GB"},
+		{1234567, "1.18 MiB", "1.23 MB", "1234567 B"},
+		{3 * KiB, "3 KiB", "3.07 kB", "3 KiB"},
+		{MaxByteSize, "8 EiB", "9.22 EB", "9223372036854775807 B"},
+	} {
+		if got := tt.b.String(); got != tt.iec {
+			t.Errorf("ByteSize(%d).String() = %q, want %q", tt.b, got, tt.iec)
+		}
+		if got := tt.b.SI(); got != tt.si {
+			t.Errorf("ByteSize(%d).SI() = %q, want %q", tt.b, got, tt.si)
+		}
+		text, err := tt.b.MarshalText()
+		if err != nil || string(text) != tt.marsh {
+			t.Errorf("ByteSize(%d).MarshalText() = %q, %v; want %q", tt.b, text, err, tt.marsh)
+		}
+		var back ByteSize
+		if err := back.UnmarshalText(text); err != nil || back != tt.b {
+			t.Errorf("%q read back as %d, %v; want %d", text, back, err, tt.b)
+		}
+	}
+	if _, err := ByteSize(-1).MarshalText(); err == nil {
+		t.Error("a negative size was marshalled")
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	for s, want := range map[string]ByteSize{
+		"512MiB":    512 * MiB,
+		"1.5 GB":    1500 * MB,
+		"1.5 gib":   1536 * MiB,
+		"64 kB":     64 * KB,
+		"64KB":      64 * KB,
+		"100":       100,
+		"100 B":     100,
+		" 2 TiB ":   2 * TiB,
+		"0.5 KiB":   512,
+		"1.0001 kB": 1000, // 1000.1 rounds down
+		"1.0005 kB": 1001, // 1000.5 rounds up
+		"8 EiB":     0,    // overflows; see below
+	} {
+		got, err := ParseByteSize(s)
+		if s == "8 EiB" {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("ParseByteSize(%q) = %d, %v; want ErrOverflow", s, got, err)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "MiB", "-1 MiB", "12 MiBs", "1e3", "0x10", "1/2 KiB", "1.2.3 MB", "ten MB", "5 m"} {
+		if got, err := ParseByteSize(s); err == nil {
+			t.Errorf("ParseByteSize(%q) = %d, want an error", s, got)
+		}
+	}
+}
+
+func TestByteSizeArithmetic(t *testing.T) {
+	check := func(op string, got ByteSize, err error, want ByteSize, overflow bool) {
+		t.Helper()
+		switch {
+		case overflow && !errors.Is(err, ErrOverflow):
+			t.Errorf("%s = %d, %v; want ErrOverflow", op, got, err)
+		case !overflow && (err != nil || got != want):
+			t.Errorf("%s = %d, %v; want %d", op, got, err, want)
+		}
+	}
+	got, err := GiB.Add(512 * MiB)
+	check("GiB + 512 MiB", got, err, 1536*MiB, false)
+	got, err = MaxByteSize.Add(1)
+	check("max + 1", got, err, 0, true)
+	got, err = (-MaxByteSize).Add(-2)
+	check("-max + -2", got, err, 0, true)
+	got, err = MiB.Sub(GiB)
+	check("MiB - GiB", got, err, MiB-GiB, false)
+	got, err = (-MaxByteSize).Sub(2)
+	check("-max - 2", got, err, 0, true)
+	got, err = GiB.Mul(1 << 32)
+	check("GiB * 2^32", got, err, 4*EiB, false)
+	got, err = GiB.Mul(1 << 33)
+	check("GiB * 2^33", got, err, 0, true)
+	got, err = (-GiB).Mul(1 << 33)
+	check("-GiB * 2^33", got, err, -8*EiB, false)
+	got, err = MiB.Mul(-3)
+	check("MiB * -3", got, err, -3*MiB, false)
+}
+
+func TestByteSizeFlagAndJSON(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	limit := 10 * MiB
+	fs.Var(&limit, "limit", "largest upload")
+	if err := fs.Parse([]string{"-limit", "1.5GiB"}); err != nil || limit != 1536*MiB {
+		t.Errorf("-limit 1.5GiB gave %v, %v", limit, err)
+	}
+	if err := fs.Parse([]string{"-limit", "lots"}); err == nil {
+		t.Error("-limit lots was accepted")
+	}
+
+	var cfg struct{ Cache, Disk, Mem ByteSize }
+	if err := json.Unmarshal([]byte(`{"Cache":"256MiB","Disk":"2 TB","Mem":1048576}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Cache != 256*MiB || cfg.Disk != 2*TB || cfg.Mem != MiB {
+		t.Errorf("got %+v", cfg)
+	}
+	data, err := json.Marshal(cfg)
+	if want := `{"Cache":"256 MiB","Disk":"2 TB","Mem":"1 MiB"}`; err != nil || string(data) != want {
+		t.Errorf("Marshal = %s, %v; want %s", data, err, want)
+	}
+	for _, bad := range []string{`{"Mem":-1}`, `{"Mem":true}`, `{"Mem":"1 parsec"}`} {
+		if err := json.Unmarshal([]byte(bad), &cfg); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Duration is a time.Duration that can be read from and written as text,
+// which time.Duration itself cannot.
+type Duration time.Duration
+
+// Day and Week are the longer units that ParseDuration accepts. They are
+// always 24 and 168 hours; daylight saving time is not considered.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+var durationUnits = []unit{
+	{"wk", float64(Week)},
+	{"d", float64(Day)},
+	{"h", float64(time.Hour)},
+	{"min", float64(time.Minute)},
+	{"s", float64(time.Second)},
+	{"ms", float64(time.Millisecond)},
+	{"µs", float64(time.Microsecond)},
+	{"ns", float64(time.Nanosecond)},
+}
+
+var durationAliases = map[string]string{
+	"weeks": "wk", "week": "wk", "days": "d", "day": "d",
+	"hours": "h", "hour": "h", "hr": "h", "mins": "min", "minutes": "min", "minute": "min",
+	"seconds": "s", "second": "s", "sec": "s", "us": "µs",
+}
+
+// String returns the duration as time.Duration formats it, as in "1h30m0s".
+func (d Duration) String() string { return time.Duration(d).String() }
+
+// ParseDuration parses a duration written as time.ParseDuration accepts,
+// such as "1h30m", or as a number and a unit, such as "90 min", "1.5 hours"
+// or "2 days".
+func ParseDuration(s string) (Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return Duration(d), nil
+	}
+	v, u, err := parse(s, "duration", durationUnits, durationAliases)
+	if err != nil {
+		return 0, err
+	}
+	ns := math.Round(u.in(v))
+	if ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return 0, fmt.Errorf("units: duration %q is out of range", s)
+	}
+	return Duration(ns), nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := ParseDuration(string(text))
+	if err == nil {
+		*d = v
+	}
+	return err
+}
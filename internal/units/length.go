@@ -0,0 +1,59 @@
+package units
+
+// Length is a distance in metres.
+type Length float64
+
+// Units of length.
+const (
+	Millimeter Length = 0.001
+	Centimeter Length = 0.01
+	Meter      Length = 1
+	Kilometer  Length = 1000
+
+	Inch Length = 0.0254
+	Foot Length = 0.3048
+	Yard Length = 0.9144
+	Mile Length = 1609.344
+)
+
+var lengthUnits = []unit{
+	{"km", float64(Kilometer)},
+	{"m", float64(Meter)},
+	{"cm", float64(Centimeter)},
+	{"mm", float64(Millimeter)},
+	{"mi", float64(Mile)},
+	{"yd", float64(Yard)},
+	{"ft", float64(Foot)},
+	{"in", float64(Inch)},
+}
+
+var lengthAliases = map[string]string{
+	"\"": "in", "'": "ft",
+	"miles": "mi", "mile": "mi", "feet": "ft", "foot": "ft", "inches": "in", "inch": "in",
+	"metres": "m", "meters": "m", "metre": "m", "meter": "m",
+}
+
+// In returns l as a number of u, as in l.In(units.Mile).
+func (l Length) In(u Length) float64 { return float64(l / u) }
+
+// String returns the length in the largest metric unit that keeps the
+// number at least 1, as in "12 km", "1.8 m" or "30.48 cm".
+func (l Length) String() string {
+	return format(float64(l), []metric{{"km", 3}, {"m", 0}, {"cm", -2}, {"mm", -3}})
+}
+
+// ParseLength parses a length such as "12 km", "1.8m", "6 ft" or "3.5 mi".
+func ParseLength(s string) (Length, error) {
+	v, u, err := parse(s, "length", lengthUnits, lengthAliases)
+	return Length(u.in(v)), err
+}
+
+func (l Length) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+func (l *Length) UnmarshalText(text []byte) error {
+	v, err := ParseLength(string(text))
+	if err == nil {
+		*l = v
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package units
+
+// Mass is a mass in kilograms.
+type Mass float64
+
+// Units of mass.
+const (
+	Milligram Mass = 1e-6
+	Gram      Mass = 1e-3
+	Kilogram  Mass = 1
+	Tonne     Mass = 1000
+
+	Pound Mass = 0.45359237
+	Ounce Mass = Pound / 16
+	Stone Mass = 14 * Pound
+)
+
+var massUnits = []unit{
+	{"t", float64(Tonne)},
+	{"kg", float64(Kilogram)},
+	{"g", float64(Gram)},
+	{"mg", float64(Milligram)},
+	{"st", float64(Stone)},
+	{"lb", float64(Pound)},
+	{"oz", float64(Ounce)},
+}
+
+var massAliases = map[string]string{
+	"lbs": "lb", "pounds": "lb", "pound": "lb", "ounces": "oz", "ounce": "oz",
+	"grams": "g", "gram": "g", "kilograms": "kg", "kilogram": "kg",
+}
+
+// In returns m as a number of u, as in m.In(units.Pound).
+func (m Mass) In(u Mass) float64 { return float64(m / u) }
+
+// String returns the mass in the largest metric unit that keeps the number
+// at least 1, as in "70 kg" or "250 g".
+func (m Mass) String() string {
+	return format(float64(m), []metric{{"t", 3}, {"kg", 0}, {"g", -3}, {"mg", -6}})
+}
+
+// ParseMass parses a mass such as "70 kg", "250g", "12 oz" or "154 lb".
+func ParseMass(s string) (Mass, error) {
+	v, u, err := parse(s, "mass", massUnits, massAliases)
+	return Mass(u.in(v)), err
+}
+
+func (m Mass) MarshalText() ([]byte, error) { return []byte(m.String()), nil }
+
+func (m *Mass) UnmarshalText(text []byte) error {
+	v, err := ParseMass(string(text))
+	if err == nil {
+		*m = v
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package units
+
+import "fmt"
+
+// Celsius, Fahrenheit and Kelvin are temperatures on each scale. The
+// scales have different zeros, so each is its own type and converting
+// between them takes a method call, not a type conversion.
+type (
+	Celsius    float64
+	Fahrenheit float64
+	Kelvin     float64
+)
+
+// AbsoluteZero is the lowest possible temperature.
+const AbsoluteZero Kelvin = 0
+
+// Temperature is a temperature on any scale.
+type Temperature interface {
+	Celsius() Celsius
+	Fahrenheit() Fahrenheit
+	Kelvin() Kelvin
+	String() string
+}
+
+var (
+	_ Temperature = Celsius(0)
+	_ Temperature = Fahrenheit(0)
+	_ Temperature = Kelvin(0)
+)
+
+func (c Celsius) Celsius() Celsius       { return c }
+func (c Celsius) Fahrenheit() Fahrenheit { return Fahrenheit(c*9/5 + 32) }
+func (c Celsius) Kelvin() Kelvin         { return Kelvin(c + 273.15) }
+
+func (f Fahrenheit) Celsius() Celsius       { return Celsius((f - 32) * 5 / 9) }
+func (f Fahrenheit) Fahrenheit() Fahrenheit { return f }
+func (f Fahrenheit) Kelvin() Kelvin         { return f.Celsius().Kelvin() }
+
+func (k Kelvin) Celsius() Celsius       { return Celsius(k - 273.15) }
+func (k Kelvin) Fahrenheit() Fahrenheit { return k.Celsius().Fahrenheit() }
+func (k Kelvin) Kelvin() Kelvin         { return k }
+
+// String returns the temperature with its unit, as in "36.6°C".
+func (c Celsius) String() string { return formatTemp(float64(c), "°C") }
+
+// String returns the temperature with its unit, as in "98.6°F".
+func (f Fahrenheit) String() string { return formatTemp(float64(f), "°F") }
+
+// String returns the temperature with its unit, as in "310 K".
+func (k Kelvin) String() string { return formatTemp(float64(k), " K") }
+
+func formatTemp(v float64, symbol string) string {
+	return formatNumber(v) + symbol
+}
+
+var (
+	temperatureUnits   = []unit{{"°C", 0}, {"°F", 0}, {"K", 0}}
+	temperatureAliases = map[string]string{"C": "°C", "F": "°F", "℃": "°C", "℉": "°F", "°K": "K"}
+)
+
+// ParseTemperature parses a temperature such as "98.6°F", "21 °C", "310K"
+// or "-40F". The result has the type of the scale the string uses. A
+// temperature below absolute zero is an error.
+func ParseTemperature(s string) (Temperature, error) {
+	v, u, err := parse(s, "temperature", temperatureUnits, temperatureAliases)
+	if err != nil {
+		return nil, err
+	}
+	var t Temperature
+	switch u.symbol {
+	case "°C":
+		t = Celsius(v)
+	case "°F":
+		t = Fahrenheit(v)
+	default:
+		t = Kelvin(v)
+	}
+	if t.Kelvin() < AbsoluteZero {
+		return nil, fmt.Errorf("units: %q is below absolute zero", s)
+	}
+	return t, nil
+}
+
+func (c Celsius) MarshalText() ([]byte, error)    { return []byte(c.String()), nil }
+func (f Fahrenheit) MarshalText() ([]byte, error) { return []byte(f.String()), nil }
+func (k Kelvin) MarshalText() ([]byte, error)     { return []byte(k.String()), nil }
+
+// UnmarshalText parses a temperature on any scale, converting it to
+// Celsius.
+func (c *Celsius) UnmarshalText(text []byte) error {
+	t, err := ParseTemperature(string(text))
+	if err == nil {
+		*c = t.Celsius()
+	}
+	return err
+}
+
+// UnmarshalText parses a temperature on any scale, converting it to
+// Fahrenheit.
+func (f *Fahrenheit) UnmarshalText(text []byte) error {
+	t, err := ParseTemperature(string(text))
+	if err == nil {
+		*f = t.Fahrenheit()
+	}
+	return err
+}
+
+// UnmarshalText parses a temperature on any scale, converting it to
+// Kelvin.
+func (k *Kelvin) UnmarshalText(text []byte) error {
+	t, err := ParseTemperature(string(text))
+	if err == nil {
+		*k = t.Kelvin()
+	}
+	return err
+}
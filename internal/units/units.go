@@ -0,0 +1,142 @@
+// Package units gives physical quantities their own types, so that a
+// length cannot be added to a mass and a temperature in Celsius cannot be
+// passed where Fahrenheit is wanted.
+//
+// It grows the Celsius and Fahrenheit types of the data types chapter into
+// temperature (with Kelvin), length, mass and duration, and its KB, MB, GB
+// and TB constants into ByteSize. Every type prints
+// with its unit, as in "98.6°F" or "12 km", parses the same form back, and
+// implements encoding.TextMarshaler and encoding.TextUnmarshaler, so values
+// round-trip through JSON, CSV and other text-based configuration.
+//
+// Length and Mass are stored in metres and kilograms, and their units are
+// constants of the type, in the style of time.Duration:
+//
+//	d := 3 * units.Mile
+//	fmt.Println(d.In(units.Kilometer)) // 4.828032
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// unit is a unit that a quantity can be written in.
+type unit struct {
+	symbol string
+	size   float64 // in the base unit of the quantity
+}
+
+// in converts v units of u to the base unit. Metric units are applied by
+// moving the decimal point, so "30.48 cm" gives exactly 0.3048 m.
+func (u unit) in(v float64) float64 {
+	e := int(math.Round(math.Log10(u.size)))
+	if math.Pow10(e) != u.size {
+		return v * u.size
+	}
+	mant, exp, _ := strings.Cut(strconv.FormatFloat(v, 'e', -1, 64), "e")
+	n, _ := strconv.Atoi(exp)
+	f, _ := strconv.ParseFloat(mant+"e"+strconv.Itoa(n+e), 64)
+	return f
+}
+
+// parse splits s into a number and one of units or its aliases, with or
+// without a space between them, and returns the number and the unit. The
+// longest matching symbol wins, so "12 km" is kilometres, not metres.
+func parse(s, quantity string, units []unit, aliases map[string]string) (float64, unit, error) {
+	s = strings.TrimSpace(s)
+	var (
+		num     float64
+		best    unit
+		matched string
+	)
+	try := func(symbol string, u unit) {
+		rest, ok := strings.CutSuffix(s, symbol)
+		if !ok || len(symbol) <= len(matched) {
+			return
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return
+		}
+		num, best, matched = f, u, symbol
+	}
+	for _, u := range units {
+		try(u.symbol, u)
+		for alias, symbol := range aliases {
+			if symbol == u.symbol {
+				try(alias, u)
+			}
+		}
+	}
+	if matched == "" {
+		return 0, unit{}, fmt.Errorf("units: cannot parse %q as a %s", s, quantity)
+	}
+	return num, best, nil
+}
+
+// metric is a unit that is a power of ten of the base unit. Values are
+// formatted in metric units only, since moving the decimal point keeps
+// every digit exact where dividing by 0.3048 would not.
+type metric struct {
+	symbol string
+	exp    int // the unit is 10**exp of the base unit
+}
+
+// format writes v, a quantity in its base unit, in the largest of units
+// in which its magnitude is at least 1, or else in the smallest. units
+// must be in decreasing size.
+func format(v float64, units []metric) string {
+	u := units[len(units)-1]
+	for _, m := range units {
+		if math.Abs(v) >= math.Pow10(m.exp) {
+			u = m
+			break
+		}
+	}
+	if v == 0 {
+		u = metric{"", 0}
+		for _, m := range units {
+			if m.exp == 0 {
+				u = m
+			}
+		}
+	}
+	return shift(formatNumber(v), -u.exp) + " " + u.symbol
+}
+
+// formatNumber formats v to 12 significant digits, which hides the
+// rounding error of float64 arithmetic, as in 6 * units.Foot, while
+// keeping any measurement anyone would write down.
+func formatNumber(v float64) string {
+	v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// shift moves the decimal point of a number formatted with 'f' by n
+// places, to the right if n is positive.
+func shift(num string, n int) string {
+	sign := ""
+	if num[0] == '-' {
+		sign, num = "-", num[1:]
+	}
+	whole, frac, _ := strings.Cut(num, ".")
+	digits, point := whole+frac, len(whole)+n
+	if point < 0 {
+		digits, point = strings.Repeat("0", -point)+digits, 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	whole = strings.TrimLeft(digits[:point], "0")
+	frac = strings.TrimRight(digits[point:], "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if frac != "" {
+		whole += "." + frac
+	}
+	return sign + whole
+}
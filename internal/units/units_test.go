@@ -0,0 +1,225 @@
+package units
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestTemperature(t *testing.T) {
+	for _, tt := range []struct {
+		t    Temperature
+		c    Celsius
+		f    Fahrenheit
+		k    Kelvin
+		text string
+	}{
+		{Celsius(100), 100, 212, 373.15, "100°C"},
+		{Fahrenheit(-40), -40, -40, 233.15, "-40°F"},
+		{Fahrenheit(98.6), 37, 98.6, 310.15, "98.6°F"},
+		{AbsoluteZero, -273.15, -459.67, 0, "0 K"},
+	} {
+		if c, f, k := tt.t.Celsius(), tt.t.Fahrenheit(), tt.t.Kelvin(); !near(float64(c), float64(tt.c)) || !near(float64(f), float64(tt.f)) || !near(float64(k), float64(tt.k)) {
+			t.Errorf("%v = %v, %v, %v; want %v, %v, %v", tt.t, c, f, k, tt.c, tt.f, tt.k)
+		}
+		if s := tt.t.String(); s != tt.text {
+			t.Errorf("String() = %q, want %q", s, tt.text)
+		}
+	}
+}
+
+func TestParseTemperature(t *testing.T) {
+	for s, want := range map[string]Temperature{
+		"98.6°F":  Fahrenheit(98.6),
+		"21 °C":   Celsius(21),
+		"-40F":    Fahrenheit(-40),
+		"310.15K": Kelvin(310.15),
+		" 0 K ":   Kelvin(0),
+		"20℃":     Celsius(20),
+	} {
+		got, err := ParseTemperature(s)
+		if err != nil || got != want {
+			t.Errorf("ParseTemperature(%q) = %#v, %v; want %#v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "98.6", "°F", "hot", "-1 K", "-300°C", "NaN°C", "1e999°C", "12 km"} {
+		if got, err := ParseTemperature(s); err == nil {
+			t.Errorf("ParseTemperature(%q) = %v, want an error", s, got)
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	if got := (3 * Mile).In(Kilometer); !near(got, 4.828032) {
+		t.Errorf("3 miles = %v km, want 4.828032", got)
+	}
+	for l, want := range map[Length]string{
+		12 * Kilometer:   "12 km",
+		1.8 * Meter:      "1.8 m",
+		Foot:             "30.48 cm",
+		6 * Foot:         "1.8288 m",
+		5 * Millimeter:   "5 mm",
+		0:                "0 m",
+		-250 * Meter:     "-250 m",
+		0.1 * Millimeter: "0.1 mm",
+	} {
+		if got := l.String(); got != want {
+			t.Errorf("Length(%g).String() = %q, want %q", float64(l), got, want)
+		}
+	}
+	for s, want := range map[string]Length{
+		"12 km":    12 * Kilometer,
+		"1.8m":     1.8 * Meter,
+		"6 ft":     6 * Foot,
+		"3.5 mi":   3.5 * Mile,
+		"12\"":     Foot,
+		"2 meters": 2 * Meter,
+		"5 mm":     5 * Millimeter,
+	} {
+		if got, err := ParseLength(s); err != nil || !near(float64(got), float64(want)) {
+			t.Errorf("ParseLength(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	// Lengths print in a form that parses back to the same value, to 12
+	// significant digits.
+	for _, l := range []Length{Foot, Mile, 6 * Foot, 1e-9, 123456.789, -Inch, 0.1 + 0.2} {
+		if got, err := ParseLength(l.String()); err != nil || math.Abs(float64(got-l)) > 1e-12*math.Abs(float64(l)) {
+			t.Errorf("%g printed as %q, which parses as %v, %v", float64(l), l, float64(got), err)
+		}
+	}
+	for _, s := range []string{"12", "km", "12 kg", "12 k m"} {
+		if _, err := ParseLength(s); err == nil {
+			t.Errorf("ParseLength(%q) succeeded", s)
+		}
+	}
+}
+
+func TestMass(t *testing.T) {
+	for s, want := range map[string]Mass{
+		"70 kg":  70 * Kilogram,
+		"250g":   250 * Gram,
+		"5 mg":   5 * Milligram,
+		"2 t":    2 * Tonne,
+		"1 lb":   Pound,
+		"16 oz":  Pound,
+		"11 st":  11 * Stone,
+		"3 lbs":  3 * Pound,
+		"-1.5 g": -1.5 * Gram,
+	} {
+		if got, err := ParseMass(s); err != nil || !near(float64(got), float64(want)) {
+			t.Errorf("ParseMass(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if got := (70 * Kilogram).String(); got != "70 kg" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (250 * Gram).String(); got != "250 g" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Pound.In(Ounce); got != 16 {
+		t.Errorf("a pound is %v ounces", got)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"1h30m":     90 * time.Minute,
+		"90 min":    90 * time.Minute,
+		"1.5 hours": 90 * time.Minute,
+		"2 days":    48 * time.Hour,
+		"1wk":       Week,
+		"250 ms":    250 * time.Millisecond,
+		"3 us":      3 * time.Microsecond,
+	} {
+		if got, err := ParseDuration(s); err != nil || time.Duration(got) != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "90", "soon", "1e9 wk"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q) succeeded", s)
+		}
+	}
+}
+
+// config is the kind of struct the types are meant for.
+type config struct {
+	Room     Celsius
+	Oven     Fahrenheit
+	Probe    Kelvin
+	Distance Length
+	Payload  Mass
+	Timeout  Duration
+}
+
+func TestJSON(t *testing.T) {
+	in := config{21.5, 350, 77, 42.195 * Kilometer, 250 * Gram, Duration(90 * time.Second)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Room":"21.5°C","Oven":"350°F","Probe":"77 K","Distance":"42.195 km","Payload":"250 g","Timeout":"1m30s"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("round trip gave %+v, want %+v", out, in)
+	}
+
+	// Any scale converts to the field's.
+	if err := json.Unmarshal([]byte(`{"Room":"212°F","Distance":"1 mi"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !near(float64(out.Room), 100) || out.Distance != Mile {
+		t.Errorf("got %v and %v", out.Room, out.Distance)
+	}
+	if err := json.Unmarshal([]byte(`{"Payload":"12 km"}`), &out); err == nil {
+		t.Error("a length was accepted as a mass")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	rows := []struct {
+		temp Celsius
+		dist Length
+	}{{-3.5, 5 * Kilometer}, {18, 400 * Meter}}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, r := range rows {
+		a, _ := r.temp.MarshalText()
+		b, _ := r.dist.MarshalText()
+		w.Write([]string{string(a), string(b)})
+	}
+	w.Flush()
+	if want := "-3.5°C,5 km\n18°C,400 m\n"; buf.String() != want {
+		t.Errorf("CSV is %q, want %q", buf.String(), want)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rec := range records {
+		var c Celsius
+		var l Length
+		if err := c.UnmarshalText([]byte(rec[0])); err != nil {
+			t.Fatal(err)
+		}
+		if err := l.UnmarshalText([]byte(rec[1])); err != nil {
+			t.Fatal(err)
+		}
+		if c != rows[i].temp || l != rows[i].dist {
+			t.Errorf("row %d read back as %v, %v", i, c, l)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var builtin = map[string]Rule{
+	"min":   minRule,
+	"max":   maxRule,
+	"len":   lenRule,
+	"email": emailRule,
+	"oneof": oneOfRule,
+}
+
+func minRule(v reflect.Value, param string) error {
+	return compare(v, param, func(c int) bool { return c >= 0 }, "at least")
+}
+
+func maxRule(v reflect.Value, param string) error {
+	return compare(v, param, func(c int) bool { return c <= 0 }, "at most")
+}
+
+func lenRule(v reflect.Value, param string) error {
+	if isNumber(v) {
+		panic("len applies to strings, slices and maps; use min and max for numbers")
+	}
+	return compare(v, param, func(c int) bool { return c == 0 }, "exactly")
+}
+
+// compare compares v, or its length, with param, and returns an error
+// unless ok accepts the result of the comparison.
+func compare(v reflect.Value, param string, ok func(int) bool, word string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("parameter %q is not a number", param))
+	}
+	if isNumber(v) {
+		if c := cmp.Compare(toFloat(v), limit); !ok(c) {
+			return fmt.Errorf("must be %s %s", word, param)
+		}
+		return nil
+	}
+	n, unit := length(v)
+	if c := cmp.Compare(float64(n), limit); !ok(c) {
+		if unit == "character" {
+			return fmt.Errorf("must be %s %s long", word, plural(param, unit))
+		}
+		return fmt.Errorf("must have %s %s", word, plural(param, unit))
+	}
+	return nil
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// length returns the length of v, in characters for a string, and the
+// word for what it counts.
+func length(v reflect.Value) (int, string) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), "character"
+	case reflect.Slice, reflect.Array:
+		return v.Len(), "element"
+	case reflect.Map:
+		return v.Len(), "entry"
+	}
+	panic(fmt.Sprintf("cannot measure a %s", v.Type()))
+}
+
+func plural(n, unit string) string {
+	if n == "1" {
+		return n + " " + unit
+	}
+	if unit == "entry" {
+		return n + " entries"
+	}
+	return n + " " + unit + "s"
+}
+
+// emailRule accepts a bare address, as net/mail parses it, and not one
+// with a display name such as "Alice <alice@example.com>".
+func emailRule(v reflect.Value, _ string) error {
+	s := mustString(v)
+	if a, err := mail.ParseAddress(s); err != nil || a.Address != s || a.Name != "" {
+		return fmt.Errorf("must be an email address")
+	}
+	return nil
+}
+
+func oneOfRule(v reflect.Value, param string) error {
+	allowed := strings.Fields(param)
+	if len(allowed) == 0 {
+		panic("oneof needs the allowed values")
+	}
+	var s string
+	switch {
+	case v.Kind() == reflect.String:
+		s = v.String()
+	case isNumber(v):
+		s = fmt.Sprint(v.Interface())
+	default:
+		panic(fmt.Sprintf("oneof cannot check a %s", v.Type()))
+	}
+	if !slices.Contains(allowed, s) {
+		return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func mustString(v reflect.Value) string {
+	if v.Kind() != reflect.String {
+		panic(fmt.Sprintf("applies to strings, not %s", v.Type()))
+	}
+	return v.String()
+}
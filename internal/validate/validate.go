@@ -0,0 +1,319 @@
+// Package validate checks structs against rules written in their tags,
+// growing the structs chapter's printTags, which only read json tags, into
+// a validator:
+//
+//	type User struct {
+//		Name  string `validate:"required"`
+//		Email string `validate:"required,email"`
+//		Age   int    `validate:"min=0,max=150"`
+//	}
+//
+// Struct walks into nested structs, and into the elements of slices,
+// arrays and maps, and reports every failure rather than the first, as
+// Errors: a list of ValidationErrors like the ones chapters 10 and 11
+// define, each with the path to its field, such as "Address.Zip" or
+// "Contacts[2].Email".
+//
+// In a slice or map field, rules before "dive" apply to the field itself
+// and rules after it to each element:
+//
+//	Tags []string `validate:"max=5,dive,required"`
+//
+// Rules are separated by commas, and take a parameter after "=". The
+// built-in ones are listed at New; Register adds more.
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ValidationError reports that one field broke one rule.
+type ValidationError struct {
+	Field   string // path from the struct passed to Struct, such as "Address.Zip"
+	Rule    string // such as "min"
+	Param   string // the rule's parameter, such as "0", or ""
+	Message string // such as "must be at least 0"
+	Value   any
+}
+
+func (e ValidationError) Error() string { return e.Field + " " + e.Message }
+
+// Errors is every ValidationError found in one struct, in field order.
+type Errors []ValidationError
+
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return "validate: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.As find a ValidationError in es; it finds the first.
+func (es Errors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// A Rule checks a field's value against the rule's parameter, which is ""
+// if the tag gave none, and returns an error if it fails. The error's text
+// becomes the ValidationError's Message, so should read on from the field
+// name: "must be at least 0". A Rule may panic if the parameter itself is
+// wrong; that is a mistake in the tag, not in the value.
+//
+// Rules are not called for nil pointers and interfaces, which only
+// "required" checks; v is what they point to.
+type Rule func(v reflect.Value, param string) error
+
+// TagName is the struct tag that rules are read from.
+const TagName = "validate"
+
+// Validator holds a set of rules. It is safe for concurrent use.
+type Validator struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// New returns a Validator with the built-in rules:
+//
+//	required     not the zero value, and not empty for slices and maps
+//	omitempty    skip the field's other rules if it is the zero value
+//	min=n max=n  at least or at most n, for numbers; for strings (in
+//	             characters), slices and maps, their length
+//	len=n        exactly n long, for strings, slices and maps
+//	email        an address such as "alice@example.com", without a name
+//	oneof=a b c  one of the space-separated values
+//	dive         apply the following rules to each element instead
+func New() *Validator {
+	v := &Validator{rules: make(map[string]Rule)}
+	for name, r := range builtin {
+		v.rules[name] = r
+	}
+	return v
+}
+
+// Register adds a rule, or replaces the one with the same name. It panics
+// if name is empty, or is one of "required", "omitempty" and "dive", which
+// are part of how fields are walked.
+func (v *Validator) Register(name string, rule Rule) {
+	switch name {
+	case "", "required", "omitempty", "dive":
+		panic(fmt.Sprintf("validate: cannot register a rule named %q", name))
+	}
+	if strings.ContainsAny(name, ",=") {
+		panic(fmt.Sprintf("validate: rule name %q contains ',' or '='", name))
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+}
+
+func (v *Validator) rule(name string) Rule {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.rules[name]
+}
+
+// Struct checks s, a struct or a pointer to one, and returns Errors
+// listing every failure, or nil. It returns some other error if s is not
+// a struct.
+//
+// It panics if a tag names a rule that v does not have, or gives a rule a
+// parameter it cannot use, as regexp.MustCompile panics on a bad
+// expression: tags are part of the program, not its input.
+func (v *Validator) Struct(s any) error {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: Struct needs a struct, got %T", s)
+	}
+	w := walker{v: v, walking: map[uintptr]bool{}}
+	w.walkStruct(rv, "")
+	if len(w.errs) == 0 {
+		return nil
+	}
+	return w.errs
+}
+
+var defaultValidator = New()
+
+// Struct checks s with the built-in rules and those added by Register.
+func Struct(s any) error { return defaultValidator.Struct(s) }
+
+// Register adds a rule to the validator that Struct uses.
+func Register(name string, rule Rule) { defaultValidator.Register(name, rule) }
+
+type walker struct {
+	v       *Validator
+	errs    Errors
+	walking map[uintptr]bool // pointers being walked into, to stop cycles
+}
+
+// walkStruct checks every exported field of the struct rv. The fields of
+// embedded structs are named as if they were the outer struct's own, as
+// Go lets code name them.
+func (w *walker) walkStruct(rv reflect.Value, path string) {
+	t := rv.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fpath := path
+		if !f.Anonymous {
+			fpath = join(path, f.Name)
+		}
+		w.walkField(rv.Field(i), fpath, f.Tag.Get(TagName))
+	}
+}
+
+// walkField checks v against the rules in tag, then walks into it: into
+// the fields of a struct, and the elements of a slice, array or map.
+func (w *walker) walkField(v reflect.Value, path, tag string) {
+	if tag == "-" {
+		return
+	}
+	var rules, elemRules []string
+	if tag != "" {
+		rules = strings.Split(tag, ",")
+	}
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		rules, elemRules = rules[:i], rules[i+1:]
+	}
+	// Without dive, elements are still walked into, for the tags of any
+	// structs in them, but have no rules of their own.
+	elemTag := strings.Join(elemRules, ",")
+
+	if !w.check(v, path, rules) {
+		return
+	}
+	// Walk into what v points to, unless it is nil, or is a pointer that
+	// is already being walked further up, which would loop for ever.
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			p := v.Pointer()
+			if w.walking[p] {
+				return
+			}
+			w.walking[p] = true
+			defer delete(w.walking, p)
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		w.walkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			w.walkField(v.Index(i), fmt.Sprintf("%s[%d]", path, i), elemTag)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+		for _, k := range keys {
+			w.walkField(v.MapIndex(k), fmt.Sprintf("%s[%v]", path, k), elemTag)
+		}
+	}
+}
+
+// indirect follows pointers and interfaces to the value underneath, and
+// returns the zero Value if it meets a nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// check applies the rules to v, and reports whether to go on into v: not
+// if it was required and missing.
+//
+// A pointer or interface counts as missing only when nil: a pointer to 0
+// is a value that was given.
+func (w *walker) check(v reflect.Value, path string, rules []string) bool {
+	val := indirect(v)
+	direct := v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface
+	missing := !val.IsValid() || (direct && isEmpty(val))
+
+	for _, r := range rules {
+		name, param, _ := strings.Cut(r, "=")
+		switch name {
+		case "":
+			continue
+		case "omitempty":
+			if missing {
+				return true
+			}
+			continue
+		case "required":
+			if missing {
+				w.fail(path, name, param, "is required", v)
+				return false
+			}
+			continue
+		}
+		rule := w.v.rule(name)
+		if rule == nil {
+			panic(fmt.Sprintf("validate: unknown rule %q on %s", name, path))
+		}
+		if !val.IsValid() {
+			continue
+		}
+		if err := callRule(rule, val, name, param, path); err != nil {
+			w.fail(path, name, param, err.Error(), val)
+		}
+	}
+	return true
+}
+
+// callRule calls rule, adding the rule and field to any panic.
+func callRule(rule Rule, v reflect.Value, name, param, path string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(fmt.Sprintf("validate: rule %s=%s on %s: %v", name, param, path, r))
+		}
+	}()
+	return rule(v, param)
+}
+
+func (w *walker) fail(path, rule, param, msg string, v reflect.Value) {
+	e := ValidationError{Field: path, Rule: rule, Param: param, Message: msg}
+	if v.IsValid() && v.CanInterface() {
+		e.Value = v.Interface()
+	}
+	w.errs = append(w.errs, e)
+}
+
+// isEmpty reports whether v is the zero value, or an empty slice or map.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type address struct {
+	Street string `validate:"required"`
+	Zip    string `validate:"required,len=5"`
+}
+
+type contact struct {
+	Email string `validate:"required,email"`
+}
+
+type Base struct {
+	Kind string `validate:"oneof=admin member"`
+}
+
+type user struct {
+	Base
+	Name     string  `validate:"required,max=10"`
+	Age      int     `validate:"min=0,max=150"`
+	Nick     *string `validate:"omitempty,min=3"`
+	Address  address
+	Billing  *address           `validate:"required"`
+	Contacts []contact          `validate:"min=1"`
+	Tags     []string           `validate:"max=3,dive,required,max=5"`
+	Scores   map[string]float64 `validate:"dive,min=0"`
+	Homes    map[string]*address
+	Ignored  string `validate:"-"`
+	secret   string `validate:"required"`
+}
+
+func valid() user {
+	return user{
+		Base:     Base{Kind: "member"},
+		Name:     "Alice",
+		Age:      30,
+		Address:  address{"Main St", "12345"},
+		Billing:  &address{"Side St", "54321"},
+		Contacts: []contact{{"alice@example.com"}},
+		Tags:     []string{"go"},
+		Scores:   map[string]float64{"x": 1},
+	}
+}
+
+// failures returns err's ValidationErrors as "Field rule" strings.
+func failures(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var es Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("error %v is not Errors", err)
+	}
+	var out []string
+	for _, e := range es {
+		out = append(out, e.Field+" "+e.Rule)
+	}
+	return out
+}
+
+func TestStruct(t *testing.T) {
+	if err := Struct(valid()); err != nil {
+		t.Fatalf("valid user: %v", err)
+	}
+	u := valid()
+	if err := Struct(&u); err != nil {
+		t.Fatalf("pointer to valid user: %v", err)
+	}
+
+	nick, short := "bo", ""
+	tests := []struct {
+		name   string
+		change func(*user)
+		want   []string
+	}{
+		{"missing name", func(u *user) { u.Name = "" }, []string{"Name required"}},
+		{"long name", func(u *user) { u.Name = "Bartholomew" }, []string{"Name max"}},
+		{"unicode name", func(u *user) { u.Name = "Åsa Öberg" }, nil}, // 9 characters, 11 bytes
+		{"age", func(u *user) { u.Age = 200 }, []string{"Age max"}},
+		{"negative age", func(u *user) { u.Age = -1 }, []string{"Age min"}},
+		{"short nick", func(u *user) { u.Nick = &nick }, []string{"Nick min"}},
+		{"empty nick", func(u *user) { u.Nick = &short }, []string{"Nick min"}},
+		{"embedded", func(u *user) { u.Kind = "guest" }, []string{"Kind oneof"}},
+		{"nested", func(u *user) { u.Address.Zip = "123" }, []string{"Address.Zip len"}},
+		{"nil pointer", func(u *user) { u.Billing = nil }, []string{"Billing required"}},
+		{"through pointer", func(u *user) { u.Billing.Street = "" }, []string{"Billing.Street required"}},
+		{"no contacts", func(u *user) { u.Contacts = nil }, []string{"Contacts min"}},
+		{"slice element", func(u *user) {
+			u.Contacts = append(u.Contacts, contact{"bob"}, contact{"Carol <carol@example.com>"})
+		}, []string{"Contacts[1].Email email", "Contacts[2].Email email"}},
+		{"dive", func(u *user) { u.Tags = []string{"go", "", "toolong"} }, []string{"Tags[1] required", "Tags[2] max"}},
+		{"too many tags", func(u *user) { u.Tags = []string{"a", "b", "c", "d"} }, []string{"Tags max"}},
+		{"map", func(u *user) { u.Scores = map[string]float64{"b": -1, "a": -2, "c": 0} }, []string{"Scores[a] min", "Scores[b] min"}},
+		{"map of structs", func(u *user) { u.Homes = map[string]*address{"x": {Street: "A"}, "y": nil} }, []string{"Homes[x].Zip required"}},
+		{"ignored", func(u *user) { u.Ignored, u.secret = "", "" }, nil},
+		{"every failure", func(u *user) {
+			u.Name, u.Age, u.Address.Street = "", 151, ""
+		}, []string{"Name required", "Age max", "Address.Street required"}},
+	}
+	for _, tt := range tests {
+		u := valid()
+		tt.change(&u)
+		got := failures(t, Struct(u))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: failures %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	u := valid()
+	u.Age, u.Address.Zip = 200, "1"
+	err := Struct(u)
+	want := "validate: Age must be at most 150; Address.Zip must be exactly 5 characters long"
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v\nwant %s", err, want)
+	}
+	var ve ValidationError
+	if !errors.As(err, &ve) || ve != (ValidationError{"Age", "max", "150", "must be at most 150", 200}) {
+		t.Errorf("errors.As found %+v", ve)
+	}
+
+	if err := Struct(42); err == nil || errors.As(err, new(Errors)) {
+		t.Errorf("Struct(42) = %v", err)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	type s struct {
+		A string         `validate:"min=1"`
+		B []int          `validate:"len=2"`
+		C map[string]int `validate:"min=1"`
+		D uint8          `validate:"oneof=1 2"`
+		E float64        `validate:"max=0.5"`
+	}
+	err := Struct(s{B: []int{1}, D: 3, E: 0.75})
+	for _, want := range []string{
+		"A must be at least 1 character long",
+		"B must have exactly 2 elements",
+		"C must have at least 1 entry",
+		"D must be one of 1, 2",
+		"E must be at most 0.5",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error %v lacks %q", err, want)
+		}
+	}
+}
+
+type node struct {
+	Name string `validate:"required"`
+	Next *node
+}
+
+func TestCycle(t *testing.T) {
+	a := &node{Name: "a"}
+	b := &node{Next: a}
+	a.Next = b
+	if got := failures(t, Struct(a)); !reflect.DeepEqual(got, []string{"Next.Name required"}) {
+		t.Errorf("failures %q", got)
+	}
+
+	// The same pointer twice, not in a cycle, is checked at both places.
+	shared := &address{}
+	type pair struct{ A, B *address }
+	if got := failures(t, Struct(pair{shared, shared})); len(got) != 4 {
+		t.Errorf("failures %q, want 4", got)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	v := New()
+	v.Register("even", func(rv reflect.Value, _ string) error {
+		if rv.Int()%2 != 0 {
+			return fmt.Errorf("must be even")
+		}
+		return nil
+	})
+	type s struct {
+		N []int `validate:"dive,even"`
+	}
+	if got := failures(t, v.Struct(s{[]int{2, 3, 4, 5}})); !reflect.DeepEqual(got, []string{"N[1] even", "N[3] even"}) {
+		t.Errorf("failures %q", got)
+	}
+
+	// The rule belongs to v only.
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), `unknown rule "even" on N[0]`) {
+				t.Errorf("unknown rule: recovered %v", r)
+			}
+		}()
+		Struct(s{[]int{2}})
+	}()
+
+	for _, name := range []string{"required", "dive", "", "a,b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			v.Register(name, nil)
+		}()
+	}
+}
+
+func TestBadTags(t *testing.T) {
+	for _, s := range []any{
+		struct {
+			A int `validate:"min=zero"`
+		}{},
+		struct {
+			A bool `validate:"max=1"`
+		}{},
+		struct {
+			A int `validate:"len=1"`
+		}{},
+		struct {
+			A int `validate:"email"`
+		}{},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(fmt.Sprint(r), "validate: rule ") {
+					t.Errorf("Struct(%#v) recovered %v", s, r)
+				}
+			}()
+			Struct(s)
+		}()
+	}
+}
@@ -0,0 +1,318 @@
+// Package workspace confines file operations to one directory and keeps
+// track of what they create, so it can all be removed afterwards.
+//
+// The file-handling chapter's examples go through a Workspace instead of
+// calling os directly. A Workspace is rooted with os.Root, so names that
+// would escape the directory (through "..", an absolute path or a symlink)
+// fail instead of touching the rest of the file system.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Workspace is a directory that examples read and write files in. Names
+// are relative to the directory and use forward slashes; an absolute name
+// inside the directory, such as File.Name returns for a file the workspace
+// opened, is accepted too.
+type Workspace struct {
+	dir  string
+	temp bool // dir was made by New and is removed by Close
+	root *os.Root
+
+	mu       sync.Mutex
+	created  map[string]bool // name -> is a directory
+	tempSeq  int
+	isClosed bool
+}
+
+// New opens a workspace in dir, which must exist. If dir is "", New makes
+// a new temporary directory, and Close removes it again.
+func New(dir string) (*Workspace, error) {
+	temp := dir == ""
+	var err error
+	if temp {
+		dir, err = os.MkdirTemp("", "gotutor-*")
+	} else {
+		dir, err = filepath.Abs(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		if temp {
+			os.RemoveAll(dir)
+		}
+		return nil, err
+	}
+	return &Workspace{dir: dir, temp: temp, root: root, created: make(map[string]bool)}, nil
+}
+
+// Dir returns the directory the workspace is rooted in.
+func (w *Workspace) Dir() string { return w.dir }
+
+func (w *Workspace) Open(name string) (*os.File, error) {
+	return w.root.Open(w.rel(name))
+}
+
+func (w *Workspace) Create(name string) (*os.File, error) {
+	return w.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (w *Workspace) OpenFile(name string, flag int, perm fs.FileMode) (*os.File, error) {
+	name = w.rel(name)
+	if flag&os.O_CREATE == 0 {
+		return w.root.OpenFile(name, flag, perm)
+	}
+	existed := w.exists(name)
+	f, err := w.root.OpenFile(name, flag, perm)
+	if err == nil && !existed {
+		w.track(name, false)
+	}
+	return f, err
+}
+
+func (w *Workspace) ReadFile(name string) ([]byte, error) {
+	return w.root.ReadFile(w.rel(name))
+}
+
+func (w *Workspace) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = w.rel(name)
+	existed := w.exists(name)
+	err := w.root.WriteFile(name, data, perm)
+	if err == nil && !existed {
+		w.track(name, false)
+	}
+	return err
+}
+
+func (w *Workspace) Stat(name string) (fs.FileInfo, error) {
+	return w.root.Stat(w.rel(name))
+}
+
+// ReadDir returns the entries of the named directory sorted by name, like
+// os.ReadDir.
+func (w *Workspace) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(w.root.FS(), w.rel(name))
+}
+
+func (w *Workspace) Mkdir(name string, perm fs.FileMode) error {
+	name = w.rel(name)
+	err := w.root.Mkdir(name, perm)
+	if err == nil {
+		w.track(name, true)
+	}
+	return err
+}
+
+func (w *Workspace) MkdirAll(name string, perm fs.FileMode) error {
+	name = w.rel(name)
+	// Note which of the directories on the way are new before making them.
+	var missing []string
+	for dir := name; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if w.exists(dir) {
+			break
+		}
+		missing = append(missing, dir)
+	}
+	err := w.root.MkdirAll(name, perm)
+	if err == nil {
+		for _, dir := range missing {
+			w.track(dir, true)
+		}
+	}
+	return err
+}
+
+func (w *Workspace) Remove(name string) error {
+	name = w.rel(name)
+	err := w.root.Remove(name)
+	if err == nil {
+		w.untrack(name)
+	}
+	return err
+}
+
+func (w *Workspace) RemoveAll(name string) error {
+	name = w.rel(name)
+	err := w.root.RemoveAll(name)
+	if err == nil {
+		w.untrack(name)
+	}
+	return err
+}
+
+// Rename moves oldname to newname. The new name counts as created if the
+// old one was; renaming a file that was already there does not make the
+// workspace responsible for removing it.
+func (w *Workspace) Rename(oldname, newname string) error {
+	oldname, newname = w.rel(oldname), w.rel(newname)
+	existed := w.exists(newname)
+	err := w.root.Rename(oldname, newname)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	isDir, wasCreated := w.created[oldname]
+	for name, dir := range w.created {
+		if rest, ok := strings.CutPrefix(name, oldname+"/"); ok {
+			delete(w.created, name)
+			w.created[newname+"/"+rest] = dir
+		}
+	}
+	delete(w.created, oldname)
+	if wasCreated && !existed {
+		w.created[newname] = isDir
+	}
+	return nil
+}
+
+// CreateTemp creates a new file in the directory dir (the workspace's own
+// directory if dir is "") and opens it for reading and writing. As with
+// os.CreateTemp, the last "*" in pattern is replaced by a string that makes
+// the name unique; here it is a counter, so runs are repeatable.
+func (w *Workspace) CreateTemp(dir, pattern string) (*os.File, error) {
+	var f *os.File
+	err := w.tempName(dir, pattern, func(name string) error {
+		var err error
+		f, err = w.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		return err
+	})
+	return f, err
+}
+
+// MkdirTemp creates a new directory in dir, named as for CreateTemp, and
+// returns its name.
+func (w *Workspace) MkdirTemp(dir, pattern string) (string, error) {
+	var created string
+	err := w.tempName(dir, pattern, func(name string) error {
+		created = name
+		return w.Mkdir(name, 0700)
+	})
+	return created, err
+}
+
+func (w *Workspace) tempName(dir, pattern string, create func(name string) error) error {
+	if strings.Contains(pattern, "/") {
+		return fmt.Errorf("workspace: pattern %q contains a path separator", pattern)
+	}
+	prefix, suffix := pattern, ""
+	if i := strings.LastIndex(pattern, "*"); i >= 0 {
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+	if dir == "" {
+		dir = "."
+	}
+	dir = w.rel(dir)
+	for try := 0; try < 10000; try++ {
+		w.mu.Lock()
+		w.tempSeq++
+		n := w.tempSeq
+		w.mu.Unlock()
+
+		err := create(path.Join(dir, prefix+strconv.Itoa(n)+suffix))
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+	}
+	return fmt.Errorf("workspace: no unused name for pattern %q", pattern)
+}
+
+// FS returns a read-only view of the workspace for use with fs.WalkDir and
+// friends.
+func (w *Workspace) FS() fs.FS { return w.root.FS() }
+
+// Created returns the names of the files and directories created through
+// the workspace that are still there, sorted. Directory names end in "/".
+func (w *Workspace) Created() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	names := make([]string, 0, len(w.created))
+	for name, dir := range w.created {
+		if dir {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Close removes everything the workspace created, and the directory itself
+// if New made it. Files and directories that were there before are left
+// alone.
+func (w *Workspace) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.isClosed {
+		return nil
+	}
+	w.isClosed = true
+
+	var errs []error
+	if w.temp {
+		errs = append(errs, w.root.Close(), os.RemoveAll(w.dir))
+	} else {
+		// Reverse order puts every entry before the directory holding it.
+		names := make([]string, 0, len(w.created))
+		for name := range w.created {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		slices.Reverse(names)
+		for _, name := range names {
+			if err := w.root.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+		errs = append(errs, w.root.Close())
+	}
+	clear(w.created)
+	return errors.Join(errs...)
+}
+
+// rel returns name relative to the workspace if it is an absolute path
+// inside it, and cleaned either way. Anything else is left for os.Root to
+// reject.
+func (w *Workspace) rel(name string) string {
+	if filepath.IsAbs(name) {
+		if r, err := filepath.Rel(w.dir, name); err == nil && filepath.IsLocal(r) {
+			name = r
+		}
+	}
+	return path.Clean(filepath.ToSlash(name))
+}
+
+func (w *Workspace) exists(name string) bool {
+	_, err := w.root.Lstat(name)
+	return err == nil
+}
+
+func (w *Workspace) track(name string, dir bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.created[name] = dir
+}
+
+func (w *Workspace) untrack(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.created, name)
+	for n := range w.created {
+		if strings.HasPrefix(n, name+"/") {
+			delete(w.created, n)
+		}
+	}
+}
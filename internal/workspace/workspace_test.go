@@ -0,0 +1,109 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTracksAndRemovesWhatItCreates(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	must(t, w.WriteFile("a.txt", []byte("a"), 0644))
+	must(t, w.WriteFile("keep.txt", []byte("overwritten"), 0644)) // existed: not ours
+	must(t, w.MkdirAll("x/y/z", 0755))
+	f, err := w.Create("x/y/b.txt")
+	must(t, err)
+	f.Close()
+	must(t, w.WriteFile("old.txt", nil, 0644))
+	must(t, w.Rename("old.txt", "new.txt"))
+	must(t, w.WriteFile("gone.txt", nil, 0644))
+	must(t, w.Remove("gone.txt"))
+
+	want := "[a.txt new.txt x/ x/y/ x/y/b.txt x/y/z/]"
+	if got := fmt.Sprint(w.Created()); got != want {
+		t.Errorf("Created() = %s, want %s", got, want)
+	}
+
+	must(t, w.Close())
+	entries, err := os.ReadDir(dir)
+	must(t, err)
+	if len(entries) != 1 || entries[0].Name() != "keep.txt" {
+		t.Errorf("after Close the directory holds %v, want only keep.txt", entries)
+	}
+}
+
+func TestTempWorkspaceIsRemoved(t *testing.T) {
+	w, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	must(t, w.MkdirAll("a/b", 0755))
+	must(t, w.Close())
+	if _, err := os.Stat(w.Dir()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("temporary directory %s survived Close", w.Dir())
+	}
+}
+
+func TestNamesCannotEscape(t *testing.T) {
+	w, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	outside := filepath.Join(t.TempDir(), "outside.txt")
+	for _, name := range []string{"../escape.txt", outside} {
+		if err := w.WriteFile(name, nil, 0644); err == nil {
+			t.Errorf("WriteFile(%q) succeeded", name)
+		}
+	}
+	if len(w.Created()) != 0 {
+		t.Errorf("Created() = %v after failed writes", w.Created())
+	}
+}
+
+func TestCreateTemp(t *testing.T) {
+	w, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	must(t, w.WriteFile("tmp_1.txt", nil, 0644)) // the first name is taken
+	f, err := w.CreateTemp("", "tmp_*.txt")
+	must(t, err)
+	defer f.Close()
+	if got := filepath.Base(f.Name()); got != "tmp_2.txt" {
+		t.Errorf("CreateTemp made %s, want tmp_2.txt", got)
+	}
+
+	dir, err := w.MkdirTemp("", "dir_*")
+	must(t, err)
+	if dir != "dir_3" {
+		t.Errorf("MkdirTemp made %s, want dir_3", dir)
+	}
+
+	// File.Name is absolute; the workspace accepts it.
+	must(t, w.Remove(f.Name()))
+	if want := "[dir_3/ tmp_1.txt]"; fmt.Sprint(w.Created()) != want {
+		t.Errorf("Created() = %v, want %s", w.Created(), want)
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}